@@ -24,35 +24,40 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/dates/special": {
-            "get": {
+        "/admin/bookings/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get special dates for a date range (admin only)",
+                "description": "Approve (confirmed) or reject (cancelled) a pending booking, or change the status of any booking. Approving a pending booking deducts its held points, rejecting releases them. A cancelled booking can only be reactivated while its room is still free (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-dates"
+                    "admin-bookings"
                 ],
-                "summary": "Get special dates",
+                "summary": "Update booking status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "From Date (YYYY-MM-DD)",
-                        "name": "from_date",
-                        "in": "query",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "To Date (YYYY-MM-DD)",
-                        "name": "to_date",
-                        "in": "query",
-                        "required": true
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateBookingStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -80,6 +85,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -87,33 +98,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/dates/calendar": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Set a special date with custom point cost (admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Expand special dates, recurring rules and weekday defaults into the point cost of every day in a range of at most 366 days. With hotel_id, the hotel's weekend days are used. With as_of, special dates are priced as they were at that moment; recurring rules and defaults always use their current values (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin-dates"
                 ],
-                "summary": "Set special date",
+                "summary": "Preview point calendar",
                 "parameters": [
                     {
-                        "description": "Special Date Information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.SpecialDateRequest"
-                        }
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hotel ID",
+                        "name": "hotel_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price special dates as of this moment (RFC3339)",
+                        "name": "as_of",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -141,6 +168,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -150,28 +183,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/dates/special/{id}": {
-            "delete": {
+        "/admin/dates/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a special date (admin only)",
+                "description": "Read every event of an .ics file (e.g. a public holiday calendar) and create or update the special date of each day it covers with the chosen type and point cost. A calendar may contain at most 1000 events and cover at most 366 days. Without apply=true only a preview of the created, updated and unchanged dates is returned (admin only)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin-dates"
                 ],
-                "summary": "Delete special date",
+                "summary": "Import special dates from an iCalendar file",
                 "parameters": [
+                    {
+                        "type": "file",
+                        "description": "iCalendar (.ics) file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "Date Rule ID",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Date type: regular, weekend or holiday (default holiday)",
+                        "name": "type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Point cost of each imported date",
+                        "name": "point_cost",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Save the changes instead of only previewing them",
+                        "name": "apply",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -208,14 +263,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/hotels": {
+        "/admin/dates/recurring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all recurring date rules (yearly dates, nth weekdays and seasonal ranges) (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-dates"
+                ],
+                "summary": "Get recurring date rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new hotel (admin only)",
+                "description": "Create a rule that prices matching days without one special date per day: yearly (month/day), nth_weekday (weekday, week, month or 0 for every month) or range (month/day to end_month/end_day, may wrap the year end). Special dates take precedence over recurring rules (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -223,17 +319,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-hotels"
+                    "admin-dates"
                 ],
-                "summary": "Create a new hotel",
+                "summary": "Create recurring date rule",
                 "parameters": [
                     {
-                        "description": "Hotel Information",
+                        "description": "Recurring Date Rule",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateHotelRequest"
+                            "$ref": "#/definitions/handlers.RecurringDateRuleRequest"
                         }
                     }
                 ],
@@ -271,14 +367,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/hotels/{id}": {
+        "/admin/dates/recurring/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update hotel information (admin only)",
+                "description": "Update a recurring date rule (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,24 +382,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-hotels"
+                    "admin-dates"
                 ],
-                "summary": "Update a hotel",
+                "summary": "Update recurring date rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Hotel ID",
+                        "description": "Recurring Date Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Hotel Information",
+                        "description": "Recurring Date Rule",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateHotelRequest"
+                            "$ref": "#/definitions/handlers.RecurringDateRuleRequest"
                         }
                     }
                 ],
@@ -352,18 +448,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a hotel (admin only)",
+                "description": "Delete a recurring date rule (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-hotels"
+                    "admin-dates"
                 ],
-                "summary": "Delete a hotel",
+                "summary": "Delete recurring date rule",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Hotel ID",
+                        "description": "Recurring Date Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -409,38 +505,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/rooms": {
-            "post": {
+        "/admin/dates/special": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new room in a hotel (admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get special dates for a date range (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-rooms"
+                    "admin-dates"
                 ],
-                "summary": "Create a new room",
+                "summary": "Get special dates",
                 "parameters": [
                     {
-                        "description": "Room Information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateRoomRequest"
-                        }
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to_date",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/utils.APISuccessResponse"
                         }
@@ -463,12 +561,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.APIErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -476,16 +568,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/rooms/availability": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Set room availability for a date range (admin only)",
+                "description": "Set a special date with custom point cost. Every change is recorded as a new version of the rule (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -493,17 +583,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-rooms"
+                    "admin-dates"
                 ],
-                "summary": "Set room availability",
+                "summary": "Set special date",
                 "parameters": [
                     {
-                        "description": "Room Availability Information",
+                        "description": "Special Date Information",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RoomAvailabilityRequest"
+                            "$ref": "#/definitions/handlers.SpecialDateRequest"
                         }
                     }
                 ],
@@ -532,12 +622,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.APIErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -547,14 +631,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/rooms/{id}": {
-            "put": {
+        "/admin/dates/special/bulk": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update room information (admin only)",
+                "description": "Create or update the special date of every selected day with one type, name and point cost. Days are selected by a list of dates, inclusive ranges, or both, at most 366 days per request. All changes are saved in one transaction and every day is reported as create, update or unchanged (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -562,24 +646,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "admin-rooms"
+                    "admin-dates"
                 ],
-                "summary": "Update a room",
+                "summary": "Bulk set special dates",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Room ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Room Information",
+                        "description": "Selected dates and special date information",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateRoomRequest"
+                            "$ref": "#/definitions/handlers.BulkSpecialDateRequest"
                         }
                     }
                 ],
@@ -608,12 +685,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.APIErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -621,28 +692,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/dates/special/bulk-delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a room (admin only)",
+                "description": "Delete the special date of every selected day. Days are selected by a list of dates, inclusive ranges, or both, at most 366 days per request. All deletions are saved in one transaction and every day is reported as delete or not_found (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-rooms"
+                    "admin-dates"
                 ],
-                "summary": "Delete a room",
+                "summary": "Bulk delete special dates",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Room ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Selected dates",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BulkDeleteSpecialDateRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -670,14 +748,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.APIErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
@@ -685,42 +757,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/rooms/{id}/availability": {
-            "get": {
+        "/admin/dates/special/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get room availability for a date range (admin only)",
+                "description": "Delete a special date. The rule's history is kept and ends with a deleted version (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin-rooms"
+                    "admin-dates"
                 ],
-                "summary": "Get room availability",
+                "summary": "Delete special date",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID",
+                        "description": "Date Rule ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "From Date (YYYY-MM-DD)",
-                        "name": "from_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "To Date (YYYY-MM-DD)",
-                        "name": "to_date",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -763,35 +821,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate a user and return a JWT token",
-                "consumes": [
-                    "application/json"
+        "/admin/dates/special/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Get every version of a special date, oldest first, with the admin who made each change and when. Deleted rules keep their history (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-dates"
                 ],
-                "summary": "Login user",
+                "summary": "Get special date history",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Date Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.AuthResponse"
+                            "$ref": "#/definitions/utils.APISuccessResponse"
                         }
                     },
                     "400": {
@@ -805,13 +863,36 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/auth/register": {
+        "/admin/hotels": {
             "post": {
-                "description": "Register a new user and create a user account",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new hotel (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -819,17 +900,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin-hotels"
                 ],
-                "summary": "Register a new user",
+                "summary": "Create a new hotel",
                 "parameters": [
                     {
-                        "description": "User Registration Info",
+                        "description": "Hotel Information",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
+                            "$ref": "#/definitions/handlers.CreateHotelRequest"
                         }
                     }
                 ],
@@ -845,34 +926,6 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/bookings": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all bookings for the authenticated user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "bookings"
-                ],
-                "summary": "Get user bookings",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Booking"
-                            }
-                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -880,6 +933,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -887,14 +946,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/hotels/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new room booking using points",
+                "description": "Update hotel information (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -902,25 +963,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "admin-hotels"
                 ],
-                "summary": "Create a new booking",
+                "summary": "Update a hotel",
                 "parameters": [
                     {
-                        "description": "Booking Information",
+                        "type": "string",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hotel Information",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateBookingRequest"
+                            "$ref": "#/definitions/handlers.UpdateHotelRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Booking"
+                            "$ref": "#/definitions/utils.APISuccessResponse"
                         }
                     },
                     "400": {
@@ -935,6 +1003,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -948,31 +1022,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/bookings/active": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all active (upcoming) bookings for the authenticated user",
+                "description": "Delete a hotel (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "admin-hotels"
+                ],
+                "summary": "Delete a hotel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get active bookings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Booking"
-                            }
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
                     "401": {
@@ -981,6 +1065,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -990,14 +1086,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/calculate": {
-            "post": {
+        "/admin/hotels/{id}/last-minute-discount": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Calculate the point cost for a booking",
+                "description": "Lower the point cost of nights within within_days days of today while the hotel's occupancy for that night is below occupancy_below percent. Set enabled to false to turn the discount off (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1005,17 +1101,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "admin-hotels"
                 ],
-                "summary": "Calculate booking point cost",
+                "summary": "Set hotel last-minute discount",
                 "parameters": [
                     {
-                        "description": "Booking Information",
+                        "type": "string",
+                        "description": "Hotel ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Last-minute discount",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CalculatePointCostRequest"
+                            "$ref": "#/definitions/handlers.LastMinuteDiscountRequest"
                         }
                     }
                 ],
@@ -1023,7 +1126,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CalculatePointCostResponse"
+                            "$ref": "#/definitions/utils.APISuccessResponse"
                         }
                     },
                     "400": {
@@ -1038,6 +1141,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1053,35 +1162,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}": {
+        "/admin/points/reconcile": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a specific booking by ID",
+                "description": "Compare each user's point balance with the sum of their point transactions (admin only)",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "bookings"
+                    "admin-points"
                 ],
-                "summary": "Get booking details",
+                "summary": "Reconcile point balances",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Report format (json or csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include users without drift",
+                        "name": "all",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Booking"
+                            "$ref": "#/definitions/utils.APISuccessResponse"
                         }
                     },
                     "400": {
@@ -1096,8 +1211,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
@@ -1111,38 +1226,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/bookings/{id}/cancel": {
+        "/admin/points/reconcile/repair": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Cancel a booking and refund points",
+                "description": "Write corrective adjustment transactions so each user's ledger matches their balance (admin only)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv"
                 ],
                 "tags": [
-                    "bookings"
+                    "admin-points"
                 ],
-                "summary": "Cancel booking",
+                "summary": "Repair point ledger drift",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Booking ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Report format (json or csv)",
+                        "name": "format",
+                        "in": "query"
                     },
                     {
-                        "description": "Cancellation Information",
+                        "description": "Repair options",
                         "name": "request",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CancelBookingRequest"
+                            "$ref": "#/definitions/handlers.RepairPointsRequest"
                         }
                     }
                 ],
@@ -1171,8 +1286,1810 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/points/year-end/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Show each user's projected carry-over and forfeiture under the current carry-over rule. Unused points are the user's ledger balance at the end of the year; the forfeit never exceeds the points still available, so points held by pending bookings are kept (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-points"
+                ],
+                "summary": "Preview year-end point forfeiture",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Point year (default current year)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include users without forfeiture",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rooms": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new room in a hotel (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rooms"
+                ],
+                "summary": "Create a new room",
+                "parameters": [
+                    {
+                        "description": "Room Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rooms/availability": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set room availability for a date range (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rooms"
+                ],
+                "summary": "Set room availability",
+                "parameters": [
+                    {
+                        "description": "Room Availability Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RoomAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rooms/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update room information (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rooms"
+                ],
+                "summary": "Update a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Room Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateRoomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a room (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rooms"
+                ],
+                "summary": "Delete a room",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/rooms/{id}/availability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get room availability for a date range (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-rooms"
+                ],
+                "summary": "Get room availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get admin-configurable settings such as point transfer limits (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-settings"
+                ],
+                "summary": "Get application settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings/carry-over": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set how many unused points carry over into the next point year: none (all carry over), cap, or percentage (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-settings"
+                ],
+                "summary": "Update year-end carry-over rule",
+                "parameters": [
+                    {
+                        "description": "Carry-over rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateCarryOverSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings/pricing": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set which days are weekend days, the point cost of weekdays and weekend days without a date rule, and the maximum cost of a date rule. Hotels may override the weekend days (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-settings"
+                ],
+                "summary": "Update default point costs",
+                "parameters": [
+                    {
+                        "description": "Default point costs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdatePricingSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings/stay-discounts": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the length-of-stay discount rules. Each rule gives either free_nights (the cheapest nights are free) or percent_off (rounded down) to stays of at least min_nights; the rule with the highest min_nights reached applies. An empty list disables the discounts (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-settings"
+                ],
+                "summary": "Update length-of-stay discounts",
+                "parameters": [
+                    {
+                        "description": "Length-of-stay discounts",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateStayDiscountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings/transfer": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enable or disable peer-to-peer point transfers and set their limits (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-settings"
+                ],
+                "summary": "Update point transfer limits",
+                "parameters": [
+                    {
+                        "description": "Transfer limits",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateTransferSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tiers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all point entitlement tiers (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Get entitlement tiers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a tier defining yearly grant size, max active bookings and booking window for matching grades and years of service (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Create an entitlement tier",
+                "parameters": [
+                    {
+                        "description": "Tier Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tiers/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a point entitlement tier (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Update an entitlement tier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tier Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a point entitlement tier (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Delete an entitlement tier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/grade": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the employee grade used to resolve the user's entitlement tier (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Set a user's grade",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grade",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetUserGradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/joined-at": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the date the user started working, which determines their years of service for tier resolution and their pro-rated grant. If the new date entitles the user to more points for the current period than already granted, the difference is granted (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-tiers"
+                ],
+                "summary": "Set a user's joined date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Joined date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetUserJoinedAtRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/points/adjust": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Credit (positive amount) or debit (negative amount) a user's points with a reason. Rejected if the available points (balance minus points held by pending bookings) would go negative unless allow_negative is set (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-points"
+                ],
+                "summary": "Adjust a user's points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjustment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdjustPointsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate a user and return a JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user and create a user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Registration Info",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all bookings for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Get user bookings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Booking"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new room booking using points. With use_pool the cost is split across the members of the user's pool, either by the given pool_shares or automatically (the user's own points first). Other members can only be charged up to the share limit they set. check_in and check_out are dates in the hotel's time zone; the booking is saved with the hotel's check-in and check-out times. With a valid quote_token from /bookings/calculate the booking is charged exactly the quoted cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Create a new booking",
+                "parameters": [
+                    {
+                        "description": "Booking Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Booking"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/active": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all active (upcoming) bookings for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Get active bookings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Booking"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/calculate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Calculate the point cost for a booking. check_in and check_out are dates in the hotel's time zone. Each night's date cost is multiplied by the hotel and room point multipliers, then last-minute and length-of-stay discounts are subtracted from the subtotal. The returned quote_token locks this cost for a short time when passed to POST /bookings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Calculate booking point cost",
+                "parameters": [
+                    {
+                        "description": "Booking Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CalculatePointCostRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CalculatePointCostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a specific booking by ID, including the per-night point breakdown saved when it was booked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Get booking details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Booking"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bookings/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a booking and refund points. Bookings cannot be cancelled within 24 hours of the hotel's local check-in time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookings"
+                ],
+                "summary": "Cancel booking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation Information",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CancelBookingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/hotels": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all hotels for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "hotels"
+                ],
+                "summary": "Get hotels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Hotel"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a pool that other users can join with its join code to combine points for one booking",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pools"
+                ],
+                "summary": "Create a point pool",
+                "parameters": [
+                    {
+                        "description": "Pool Information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatePoolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Join a pool using the join code shared by one of its members",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pools"
+                ],
+                "summary": "Join a point pool",
+                "parameters": [
+                    {
+                        "description": "Join Code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JoinPoolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Leave the pool the authenticated user belongs to. Bookings already paid by the pool keep their split",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pools"
+                ],
+                "summary": "Leave my point pool",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/utils.APIErrorResponse"
                         }
@@ -1186,29 +3103,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/hotels": {
+        "/pools/mine": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all hotels for the authenticated user",
+                "description": "Get the pool the authenticated user belongs to, with each member's available points",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "hotels"
+                    "pools"
                 ],
-                "summary": "Get hotels",
+                "summary": "Get my point pool",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Hotel"
-                            }
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pools/share-limit": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Consent to other pool members using up to max_points of the authenticated user's points in one booking. 0 (the default) means other members cannot charge the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pools"
+                ],
+                "summary": "Set my pool share limit",
+                "parameters": [
+                    {
+                        "description": "Share limit",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetShareLimitRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APISuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.APIErrorResponse"
                         }
                     },
                     "401": {
@@ -1228,6 +3205,40 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "handlers.AdjustPointsRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "comment",
+                "reason_code"
+            ],
+            "properties": {
+                "allow_negative": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "amount": {
+                    "type": "integer",
+                    "example": -2
+                },
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Duplicate booking deduction"
+                },
+                "reason_code": {
+                    "type": "string",
+                    "enum": [
+                        "correction",
+                        "compensation",
+                        "goodwill",
+                        "policy",
+                        "other"
+                    ],
+                    "example": "correction"
+                }
+            }
+        },
         "handlers.AuthResponse": {
             "type": "object",
             "properties": {
@@ -1236,6 +3247,67 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.BulkDeleteSpecialDateRequest": {
+            "type": "object",
+            "properties": {
+                "dates": {
+                    "description": "Format YYYY-MM-DD",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25"
+                    ]
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SpecialDateRangeRequest"
+                    }
+                }
+            }
+        },
+        "handlers.BulkSpecialDateRequest": {
+            "type": "object",
+            "required": [
+                "point_cost",
+                "type"
+            ],
+            "properties": {
+                "dates": {
+                    "description": "Format YYYY-MM-DD",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Libur Akhir Tahun"
+                },
+                "point_cost": {
+                    "description": "Paling tinggi max_point_cost pada pengaturan",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SpecialDateRangeRequest"
+                    }
+                },
+                "type": {
+                    "description": "\"regular\", \"weekend\", \"holiday\"",
+                    "type": "string",
+                    "example": "holiday"
+                }
+            }
+        },
         "handlers.CalculatePointCostRequest": {
             "type": "object",
             "required": [
@@ -1269,7 +3341,26 @@ const docTemplate = `{
                         "$ref": "#/definitions/handlers.DailyPointCost"
                     }
                 },
+                "discounts": {
+                    "description": "Potongan yang mengurangi subtotal",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookingDiscount"
+                    }
+                },
                 "point_cost": {
+                    "description": "Subtotal dikurangi potongan",
+                    "type": "integer"
+                },
+                "quote_expires_at": {
+                    "type": "string"
+                },
+                "quote_token": {
+                    "description": "QuoteToken dapat dikirim ke POST /bookings agar dikenai point_cost ini sampai QuoteExpiresAt",
+                    "type": "string"
+                },
+                "subtotal": {
+                    "description": "Jumlah biaya semua malam sebelum potongan",
                     "type": "integer"
                 }
             }
@@ -1306,9 +3397,25 @@ const docTemplate = `{
                     "type": "string",
                     "example": "60f1a5c29f48e1a8e8a8b122"
                 },
+                "pool_shares": {
+                    "description": "PoolShares adalah bagian point tiap anggota pool, jika kosong point dibagi otomatis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.PoolShareRequest"
+                    }
+                },
+                "quote_token": {
+                    "description": "QuoteToken dari POST /bookings/calculate, jika diisi booking dikenai biaya quote tersebut",
+                    "type": "string"
+                },
                 "room_id": {
                     "type": "string",
                     "example": "60f1a5c29f48e1a8e8a8b123"
+                },
+                "use_pool": {
+                    "description": "UsePool membayar booking bersama anggota pool pemesan",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
@@ -1325,6 +3432,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Jl. MH Thamrin No. 1"
                 },
+                "check_in_time": {
+                    "description": "Format HH:MM waktu lokal, default 14:00",
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "check_out_time": {
+                    "description": "Format HH:MM waktu lokal, default 12:00",
+                    "type": "string",
+                    "example": "12:00"
+                },
                 "city": {
                     "type": "string",
                     "example": "Jakarta"
@@ -1340,6 +3457,41 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Grand Hotel Jakarta"
+                },
+                "point_multiplier": {
+                    "description": "PointMultiplier adalah pengali biaya point untuk semua kamar hotel, default 1",
+                    "type": "number",
+                    "maximum": 10,
+                    "example": 1.5
+                },
+                "time_zone": {
+                    "description": "TimeZone adalah zona waktu IANA hotel, default Asia/Jakarta (WIB)",
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                },
+                "weekend_days": {
+                    "description": "WeekendDays menggantikan hari weekend pengaturan untuk hotel ini (0 = Minggu ... 6 = Sabtu)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        5,
+                        6
+                    ]
+                }
+            }
+        },
+        "handlers.CreatePoolRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Keluarga Santoso"
                 }
             }
         },
@@ -1372,12 +3524,22 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Deluxe Room"
+                },
+                "point_multiplier": {
+                    "description": "PointMultiplier adalah pengali biaya point kamar di atas pengali hotel, default 1",
+                    "type": "number",
+                    "maximum": 10,
+                    "example": 2
                 }
             }
         },
         "handlers.DailyPointCost": {
             "type": "object",
             "properties": {
+                "base_point_cost": {
+                    "description": "Komposisi biaya: point_cost = base_point_cost x hotel_multiplier x room_multiplier (dibulatkan)",
+                    "type": "integer"
+                },
                 "date": {
                     "description": "Format YYYY-MM-DD",
                     "type": "string"
@@ -1386,12 +3548,66 @@ const docTemplate = `{
                     "description": "\"regular\", \"weekend\", \"holiday\"",
                     "type": "string"
                 },
+                "discount_points": {
+                    "description": "Potongan last-minute malam ini",
+                    "type": "integer"
+                },
+                "hotel_multiplier": {
+                    "type": "number"
+                },
                 "name": {
                     "description": "Nama hari libur jika ada",
                     "type": "string"
                 },
                 "point_cost": {
                     "type": "integer"
+                },
+                "room_multiplier": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.JoinPoolRequest": {
+            "type": "object",
+            "required": [
+                "join_code"
+            ],
+            "properties": {
+                "join_code": {
+                    "type": "string",
+                    "example": "3FA9C2D1"
+                }
+            }
+        },
+        "handlers.LastMinuteDiscountRequest": {
+            "type": "object",
+            "required": [
+                "enabled"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "occupancy_below": {
+                    "description": "Persen okupansi hotel",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 50
+                },
+                "percent_off": {
+                    "description": "Persen potongan per malam, dibulatkan ke bawah",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 30
+                },
+                "within_days": {
+                    "description": "Malam dalam sekian hari dari hari ini",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
                 }
             }
         },
@@ -1405,8 +3621,120 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PoolShareRequest": {
+            "type": "object",
+            "required": [
+                "point_cost",
+                "user_id"
+            ],
+            "properties": {
+                "point_cost": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "60f1a5c29f48e1a8e8a8b124"
+                }
+            }
+        },
+        "handlers.RecurringDateRuleRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "point_cost",
+                "recurrence",
+                "type"
+            ],
+            "properties": {
+                "day": {
+                    "description": "Untuk yearly dan range",
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 0,
+                    "example": 17
+                },
+                "end_day": {
+                    "description": "Untuk range",
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "end_month": {
+                    "description": "Untuk range",
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "month": {
+                    "description": "0 untuk nth_weekday setiap bulan",
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 0,
+                    "example": 8
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hari Kemerdekaan"
+                },
+                "point_cost": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "recurrence": {
+                    "type": "string",
+                    "enum": [
+                        "yearly",
+                        "nth_weekday",
+                        "range"
+                    ],
+                    "example": "yearly"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "regular",
+                        "weekend",
+                        "holiday"
+                    ],
+                    "example": "holiday"
+                },
+                "valid_from": {
+                    "description": "Opsional, format YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "valid_until": {
+                    "description": "Opsional, format YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2030-12-31"
+                },
+                "week": {
+                    "description": "-1 untuk minggu terakhir, untuk nth_weekday",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": -1,
+                    "example": 2
+                },
+                "weekday": {
+                    "description": "0 = Minggu, untuk nth_weekday",
+                    "type": "integer",
+                    "maximum": 6,
+                    "minimum": 0,
+                    "example": 1
                 }
             }
         },
@@ -1430,6 +3758,15 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.RepairPointsRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
         "handlers.RoomAvailabilityRequest": {
             "type": "object",
             "required": [
@@ -1469,6 +3806,59 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.SetShareLimitRequest": {
+            "type": "object",
+            "properties": {
+                "max_points": {
+                    "description": "0 = anggota lain tidak bisa memakai point saya",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "handlers.SetUserGradeRequest": {
+            "type": "object",
+            "properties": {
+                "grade": {
+                    "description": "Kosong untuk menghapus grade",
+                    "type": "string",
+                    "example": "G5"
+                }
+            }
+        },
+        "handlers.SetUserJoinedAtRequest": {
+            "type": "object",
+            "required": [
+                "joined_at"
+            ],
+            "properties": {
+                "joined_at": {
+                    "description": "Format YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2020-07-01"
+                }
+            }
+        },
+        "handlers.SpecialDateRangeRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "description": "Format YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2025-12-20"
+                },
+                "to": {
+                    "description": "Format YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2026-01-03"
+                }
+            }
+        },
         "handlers.SpecialDateRequest": {
             "type": "object",
             "required": [
@@ -1487,8 +3877,8 @@ const docTemplate = `{
                     "example": "Hari Natal"
                 },
                 "point_cost": {
+                    "description": "Paling tinggi max_point_cost pada pengaturan",
                     "type": "integer",
-                    "maximum": 3,
                     "minimum": 1,
                     "example": 3
                 },
@@ -1499,6 +3889,125 @@ const docTemplate = `{
                 }
             }
         },
+        "handlers.StayDiscountRequest": {
+            "type": "object",
+            "required": [
+                "min_nights"
+            ],
+            "properties": {
+                "free_nights": {
+                    "description": "Malam termurah yang digratiskan",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 1
+                },
+                "min_nights": {
+                    "type": "integer",
+                    "minimum": 2,
+                    "example": 7
+                },
+                "percent_off": {
+                    "description": "Persen potongan, dibulatkan ke bawah",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 0
+                }
+            }
+        },
+        "handlers.TierRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "annual_points": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 36
+                },
+                "booking_window_days": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 180
+                },
+                "grades": {
+                    "description": "Kosong berarti semua grade",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "G5",
+                        "G6"
+                    ]
+                },
+                "max_active_bookings": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 3
+                },
+                "min_years_of_service": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Senior"
+                }
+            }
+        },
+        "handlers.UpdateBookingStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "confirmed",
+                        "completed",
+                        "cancelled"
+                    ],
+                    "example": "confirmed"
+                }
+            }
+        },
+        "handlers.UpdateCarryOverSettingsRequest": {
+            "type": "object",
+            "required": [
+                "mode"
+            ],
+            "properties": {
+                "cap": {
+                    "description": "Untuk mode cap",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "cap",
+                        "percentage"
+                    ],
+                    "example": "cap"
+                },
+                "percentage": {
+                    "description": "Untuk mode percentage",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 50
+                }
+            }
+        },
         "handlers.UpdateHotelRequest": {
             "type": "object",
             "properties": {
@@ -1506,6 +4015,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Updated address"
                 },
+                "check_in_time": {
+                    "description": "Format HH:MM waktu lokal",
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "check_out_time": {
+                    "description": "Format HH:MM waktu lokal",
+                    "type": "string",
+                    "example": "12:00"
+                },
                 "city": {
                     "type": "string",
                     "example": "Updated city"
@@ -1521,6 +4040,65 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "New Hotel Name"
+                },
+                "point_multiplier": {
+                    "description": "PointMultiplier adalah pengali biaya point untuk semua kamar hotel",
+                    "type": "number",
+                    "maximum": 10,
+                    "example": 1.5
+                },
+                "time_zone": {
+                    "description": "TimeZone adalah zona waktu IANA hotel",
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                },
+                "weekend_days": {
+                    "description": "WeekendDays menggantikan hari weekend pengaturan untuk hotel ini, [] kembali ikut pengaturan",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        5,
+                        6
+                    ]
+                }
+            }
+        },
+        "handlers.UpdatePricingSettingsRequest": {
+            "type": "object",
+            "required": [
+                "max_point_cost",
+                "weekday_cost",
+                "weekend_cost"
+            ],
+            "properties": {
+                "max_point_cost": {
+                    "description": "Biaya tertinggi tanggal khusus dan aturan berulang",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "weekday_cost": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "weekend_cost": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                },
+                "weekend_days": {
+                    "description": "0 = Minggu ... 6 = Sabtu",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        0,
+                        6
+                    ]
                 }
             }
         },
@@ -1546,6 +4124,53 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "Superior Room"
+                },
+                "point_multiplier": {
+                    "description": "PointMultiplier adalah pengali biaya point kamar di atas pengali hotel",
+                    "type": "number",
+                    "maximum": 10,
+                    "example": 2
+                }
+            }
+        },
+        "handlers.UpdateStayDiscountsRequest": {
+            "type": "object",
+            "properties": {
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.StayDiscountRequest"
+                    }
+                }
+            }
+        },
+        "handlers.UpdateTransferSettingsRequest": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "min_amount"
+            ],
+            "properties": {
+                "annual_limit": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 24
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_amount": {
+                    "description": "0 = tanpa batas",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "min_amount": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
                 }
             }
         },
@@ -1561,21 +4186,102 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "discounts": {
+                    "description": "Potongan yang mengurangi biaya point",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookingDiscount"
+                    }
+                },
+                "hold_until": {
+                    "description": "Batas persetujuan booking pending",
+                    "type": "string"
+                },
                 "hotel_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "nights": {
+                    "description": "Rincian biaya per malam saat booking dibuat",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookingNight"
+                    }
+                },
                 "point_cost": {
+                    "description": "Setelah potongan",
                     "type": "integer"
                 },
+                "pool_id": {
+                    "description": "Pool yang membayar booking",
+                    "type": "string"
+                },
                 "room_id": {
                     "type": "string"
                 },
+                "shares": {
+                    "description": "Pembagian point antar anggota pool",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BookingShare"
+                    }
+                },
                 "status": {
-                    "description": "\"pending\", \"confirmed\", \"cancelled\"",
+                    "description": "\"pending\", \"confirmed\", \"completed\", \"cancelled\"",
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "models.BookingDiscount": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookingNight": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "day_type": {
+                    "description": "\"regular\", \"weekend\", \"holiday\"",
+                    "type": "string"
+                },
+                "discount_points": {
+                    "description": "Potongan last-minute",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "point_cost": {
+                    "type": "integer"
+                },
+                "rule_id": {
+                    "description": "DateRule atau RecurringDateRule yang menentukan biaya",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BookingShare": {
+            "type": "object",
+            "properties": {
+                "point_cost": {
+                    "type": "integer"
                 },
                 "user_id": {
                     "type": "string"
@@ -1588,6 +4294,16 @@ const docTemplate = `{
                 "address": {
                     "type": "string"
                 },
+                "check_in_time": {
+                    "description": "Format HH:MM waktu lokal",
+                    "type": "string",
+                    "example": "14:00"
+                },
+                "check_out_time": {
+                    "description": "Format HH:MM waktu lokal",
+                    "type": "string",
+                    "example": "12:00"
+                },
                 "city": {
                     "type": "string"
                 },
@@ -1603,11 +4319,49 @@ const docTemplate = `{
                 "image": {
                     "type": "string"
                 },
+                "last_minute_discount": {
+                    "description": "LastMinuteDiscount menurunkan biaya malam yang dekat dan masih sepi, nil jika tidak aktif",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LastMinuteDiscount"
+                        }
+                    ]
+                },
                 "name": {
                     "type": "string"
                 },
+                "point_multiplier": {
+                    "description": "PointMultiplier dikalikan dengan biaya point tanggal untuk semua kamar di hotel ini",
+                    "type": "number"
+                },
+                "time_zone": {
+                    "description": "TimeZone adalah nama zona waktu IANA hotel (Asia/Jakarta, Asia/Makassar, Asia/Jayapura).\nBatas hari, \"hari ini\" dan jam check-in/check-out dihitung di zona ini.",
+                    "type": "string",
+                    "example": "Asia/Makassar"
+                },
                 "updated_at": {
                     "type": "string"
+                },
+                "weekend_days": {
+                    "description": "WeekendDays menggantikan hari weekend dari pengaturan untuk hotel ini, kosong berarti ikut pengaturan",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.LastMinuteDiscount": {
+            "type": "object",
+            "properties": {
+                "occupancy_below": {
+                    "type": "integer"
+                },
+                "percent_off": {
+                    "type": "integer"
+                },
+                "within_days": {
+                    "type": "integer"
                 }
             }
         },
@@ -33,6 +33,7 @@ import (
 	"hotel-point-app/internal/handlers"
 	"hotel-point-app/internal/middleware"
 	"hotel-point-app/internal/repositories"
+	"hotel-point-app/internal/scheduler"
 	"hotel-point-app/internal/services"
 	"hotel-point-app/pkg/database"
 
@@ -61,26 +62,49 @@ func main() {
 		log.Fatalf("Failed to connect to MongoDB: %v", err)
 	}
 
+	if err := repositories.EnsureIndexes(db); err != nil {
+		log.Fatalf("Failed to create MongoDB indexes: %v", err)
+	}
+
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(db)
 	hotelRepo := repositories.NewHotelRepository(db)
 	bookingRepo := repositories.NewBookingRepository(db)
 	dateRepo := repositories.NewDateRepository(db)
+	lotRepo := repositories.NewPointLotRepository(db)
+	tierRepo := repositories.NewTierRepository(db)
+	settingsRepo := repositories.NewSettingsRepository(db)
+	yearEndRepo := repositories.NewYearEndRepository(db)
+	poolRepo := repositories.NewPoolRepository(db)
+	txManager := repositories.NewTransactionManager(db)
 
 	// Initialize services
-	authService := services.NewAuthService(userRepo, cfg.JWT.Secret, cfg.JWT.ExpiryHours)
+	tierService := services.NewTierService(tierRepo, userRepo)
+	grantService := services.NewGrantService(userRepo, lotRepo, txManager, tierService, cfg.Grant.Points, cfg.Grant.Period, cfg.Points.ExpiryYears, cfg.Grant.ProrateRounding)
+	authService := services.NewAuthService(userRepo, grantService, cfg.JWT.Secret, cfg.JWT.ExpiryHours)
 	hotelService := services.NewHotelService(hotelRepo)
-	pointService := services.NewPointService(userRepo)
-	dateService := services.NewDateService(dateRepo)
-	bookingService := services.NewBookingService(bookingRepo, userRepo, hotelRepo, dateService, pointService)
+	settingsService := services.NewSettingsService(settingsRepo)
+	pointService := services.NewPointService(userRepo, lotRepo, txManager, settingsService)
+	dateService := services.NewDateService(dateRepo, txManager, settingsService)
+	pricingService := services.NewPricingService(dateRepo, hotelRepo, bookingRepo, settingsService)
+	poolService := services.NewPoolService(poolRepo, userRepo)
+	reconcileService := services.NewReconcileService(userRepo, txManager)
+	statementService := services.NewStatementService(userRepo, bookingRepo, hotelRepo)
+	yearEndService := services.NewYearEndService(userRepo, lotRepo, yearEndRepo, txManager, settingsService)
+	bookingService := services.NewBookingService(
+		bookingRepo, userRepo, lotRepo, hotelRepo, txManager, pricingService, pointService, tierService, poolService,
+		cfg.Booking.ApprovalRequired, time.Duration(cfg.Booking.HoldHours)*time.Hour,
+		cfg.Booking.QuoteSecret, time.Duration(cfg.Booking.QuoteMinutes)*time.Minute,
+	)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
-	userHandler := handlers.NewUserHandler(authService, pointService)
+	userHandler := handlers.NewUserHandler(authService, pointService, statementService)
 	hotelHandler := handlers.NewHotelHandler(hotelService, authService)
 	bookingHandler := handlers.NewBookingHandler(bookingService, authService)
+	poolHandler := handlers.NewPoolHandler(poolService)
 
-	adminHandler := handlers.NewAdminHandler(hotelService, dateService)
+	adminHandler := handlers.NewAdminHandler(hotelService, dateService, pricingService, reconcileService, settingsService, pointService, yearEndService, tierService, grantService)
 
 	// Initialize Gin router
 	router := gin.Default()
@@ -114,6 +138,8 @@ func main() {
 			protected.PUT("/users/profile", userHandler.UpdateProfile)
 			protected.GET("/users/points", userHandler.GetPointBalance)
 			protected.GET("/users/points/history", userHandler.GetPointHistory)
+			protected.GET("/users/points/statement", userHandler.GetPointStatement)
+			protected.POST("/users/points/transfer", userHandler.TransferPoints)
 
 			// Hotel routes
 			protected.GET("/hotels", hotelHandler.GetHotels)
@@ -126,6 +152,13 @@ func main() {
 			protected.POST("/bookings", bookingHandler.CreateBooking)
 			protected.GET("/bookings", bookingHandler.GetBookings)
 			protected.GET("/bookings/:id", bookingHandler.GetBookingById)
+
+			// Point pool routes
+			protected.GET("/pools/mine", poolHandler.GetPool)
+			protected.POST("/pools", poolHandler.CreatePool)
+			protected.POST("/pools/join", poolHandler.JoinPool)
+			protected.POST("/pools/leave", poolHandler.LeavePool)
+			protected.PUT("/pools/share-limit", poolHandler.SetShareLimit)
 		}
 
 		// Admin routes (would have its own middleware)
@@ -137,6 +170,7 @@ func main() {
 			admin.POST("/hotels", adminHandler.CreateHotel)
 			admin.PUT("/hotels/:id", adminHandler.UpdateHotel)
 			admin.DELETE("/hotels/:id", adminHandler.DeleteHotel)
+			admin.PUT("/hotels/:id/last-minute-discount", adminHandler.SetHotelLastMinuteDiscount)
 
 			// Room management
 			admin.POST("/rooms", adminHandler.CreateRoom)
@@ -150,10 +184,121 @@ func main() {
 			// Special date management
 			admin.POST("/dates/special", adminHandler.SetSpecialDate)
 			admin.GET("/dates/special", adminHandler.GetSpecialDates)
+			admin.POST("/dates/special/bulk", adminHandler.BulkSetSpecialDates)
+			admin.POST("/dates/special/bulk-delete", adminHandler.BulkDeleteSpecialDates)
 			admin.DELETE("/dates/special/:id", adminHandler.DeleteSpecialDate)
+			admin.GET("/dates/special/:id/history", adminHandler.GetSpecialDateHistory)
+			admin.GET("/dates/recurring", adminHandler.GetRecurringDates)
+			admin.POST("/dates/recurring", adminHandler.CreateRecurringDate)
+			admin.PUT("/dates/recurring/:id", adminHandler.UpdateRecurringDate)
+			admin.DELETE("/dates/recurring/:id", adminHandler.DeleteRecurringDate)
+			admin.GET("/dates/calendar", adminHandler.GetDateCalendar)
+			admin.POST("/dates/import", adminHandler.ImportCalendar)
+
+			// Booking approval
+			admin.PUT("/bookings/:id/status", bookingHandler.UpdateBookingStatus)
+
+			// Point ledger
+			admin.GET("/points/reconcile", adminHandler.ReconcilePoints)
+			admin.POST("/points/reconcile/repair", adminHandler.RepairPoints)
+			admin.POST("/users/:id/points/adjust", adminHandler.AdjustUserPoints)
+			admin.GET("/points/year-end/preview", adminHandler.PreviewYearEnd)
+
+			// Entitlement tiers
+			admin.GET("/tiers", adminHandler.GetTiers)
+			admin.POST("/tiers", adminHandler.CreateTier)
+			admin.PUT("/tiers/:id", adminHandler.UpdateTier)
+			admin.DELETE("/tiers/:id", adminHandler.DeleteTier)
+			admin.PUT("/users/:id/grade", adminHandler.SetUserGrade)
+			admin.PUT("/users/:id/joined-at", adminHandler.SetUserJoinedAt)
+
+			// Settings
+			admin.GET("/settings", adminHandler.GetSettings)
+			admin.PUT("/settings/transfer", adminHandler.UpdateTransferSettings)
+			admin.PUT("/settings/carry-over", adminHandler.UpdateCarryOverSettings)
+			admin.PUT("/settings/pricing", adminHandler.UpdatePricingSettings)
+			admin.PUT("/settings/stay-discounts", adminHandler.UpdateStayDiscounts)
 		}
 	}
 
+	// Background jobs
+	jobs := scheduler.New()
+	if cfg.Grant.SchedulerEnabled {
+		jobs.Add(scheduler.Job{
+			Name:     "point-grant",
+			Interval: time.Duration(cfg.Grant.IntervalMinutes) * time.Minute,
+			Run: func() error {
+				result, err := grantService.RunGrants(grantService.CurrentPeriod(time.Now()))
+				if err != nil {
+					return err
+				}
+				if result.Granted > 0 || result.Failed > 0 {
+					log.Printf("Point grant %s: %d granted, %d skipped, %d failed", result.Period, result.Granted, result.Skipped, result.Failed)
+				}
+				return nil
+			},
+		})
+	}
+
+	jobs.Add(scheduler.Job{
+		Name:     "point-expiry",
+		Interval: time.Duration(cfg.Points.ExpiryIntervalMinutes) * time.Minute,
+		Run: func() error {
+			expired, err := pointService.ExpirePoints(time.Now())
+			if err != nil {
+				return err
+			}
+			if expired > 0 {
+				log.Printf("Point expiry: %d lots expired", expired)
+			}
+			return nil
+		},
+	})
+
+	jobs.Add(scheduler.Job{
+		Name:     "booking-hold-expiry",
+		Interval: time.Duration(cfg.Booking.HoldIntervalMinutes) * time.Minute,
+		Run: func() error {
+			expired, err := bookingService.ExpirePendingBookings(time.Now())
+			if err != nil {
+				return err
+			}
+			if expired > 0 {
+				log.Printf("Booking hold expiry: %d pending bookings cancelled", expired)
+			}
+			return nil
+		},
+	})
+
+	// Tahun point hanya ditutup mulai tahun yang ditentukan admin, agar deploy pertama tidak
+	// menghanguskan point tahun lalu dengan aturan carry-over yang belum ditinjau
+	if cfg.Points.YearEndFirstYear > 0 {
+		jobs.Add(scheduler.Job{
+			Name:     "year-end-carry-over",
+			Interval: time.Duration(cfg.Points.YearEndIntervalMinutes) * time.Minute,
+			Run: func() error {
+				year := time.Now().Year() - 1
+				if year < cfg.Points.YearEndFirstYear {
+					return nil
+				}
+
+				closure, closed, err := yearEndService.CloseYear(year)
+				if err != nil {
+					return err
+				}
+				if closed {
+					log.Printf("Point year %d closed: %d points forfeited by %d users", closure.Year, closure.PointsForfeited, closure.UsersForfeited)
+				}
+				return nil
+			},
+		})
+	} else {
+		log.Println("Year-end carry-over is disabled, set POINT_YEAR_END_FIRST_YEAR to enable it")
+	}
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	jobs.Start(jobCtx)
+
 	// Start server
 	srv := &http.Server{
 		Addr:    ":" + cfg.Server.Port,
@@ -173,6 +318,9 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
+	jobs.Wait()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -209,16 +357,27 @@ User:
 
 - Get Point Balance: GET /users/points
   Authorization: Bearer Token
-  Response: { "point_balance": number }
+  Response: { "point_balance": number, "held_points": number, "available_points": number, "breakdown": [{ "expires_at": "datetime|null", "points": number }] }
 
 - Get Point History: GET /users/points/history
   Authorization: Bearer Token
-  Response: { "transactions": [PointTransaction objects] }
+  Query: from_date=YYYY-MM-DD, to_date=YYYY-MM-DD, type=string (comma separated), page=number, limit=number
+  Response: { "total": number, "page": number, "limit": number, "total_pages": number, "has_next": bool, "has_previous": bool, "data": [PointTransaction objects with "balance_after"] }
+
+- Get Point Statement: GET /users/points/statement
+  Authorization: Bearer Token
+  Query: year=number (default current year), format=csv|pdf (default csv)
+  Response: File attachment with opening balance, transactions and closing balance
+
+- Transfer Points: POST /users/points/transfer
+  Authorization: Bearer Token
+  Body: { "recipient_email": "string", "amount": number, "note": "string" }
+  Response: { "transfer_id": "string", "recipient_name": "string", "recipient_email": "string", "amount": number, "note": "string", "point_balance": number, "transaction": PointTransaction }
 
 Hotels:
 - Get All Hotels: GET /hotels
   Authorization: Bearer Token
-  Response: { "hotels": [Hotel objects] }
+  Response: { "hotels": [Hotel objects with "time_zone", "check_in_time" and "check_out_time"] }
 
 - Get Hotel by ID: GET /hotels/:id
   Authorization: Bearer Token
@@ -236,12 +395,13 @@ Bookings:
 - Calculate Point Cost: POST /bookings/calculate
   Authorization: Bearer Token
   Body: { "room_id": "string", "check_in": "YYYY-MM-DD", "check_out": "YYYY-MM-DD" }
-  Response: { "point_cost": number }
+  Response: { "subtotal": number, "discounts": [{ "type": "length_of_stay|last_minute", "description": "string", "points": number }], "point_cost": number, "daily_details": [DailyPointCost objects], "quote_token": "string", "quote_expires_at": "datetime" }
 
 - Create Booking: POST /bookings
   Authorization: Bearer Token
-  Body: { "hotel_id": "string", "room_id": "string", "check_in": "YYYY-MM-DD", "check_out": "YYYY-MM-DD" }
+  Body: { "hotel_id": "string", "room_id": "string", "check_in": "YYYY-MM-DD", "check_out": "YYYY-MM-DD", "use_pool": bool, "pool_shares": [{ "user_id": "string", "point_cost": number }], "quote_token": "string" }
   Response: { "message": "Booking created successfully" }
+  Note: check_in and check_out are dates in the hotel's time zone. The booking's check_in and check_out are saved at the hotel's check-in and check-out times
 
 - Get User Bookings: GET /bookings
   Authorization: Bearer Token
@@ -249,5 +409,29 @@ Bookings:
 
 - Get Booking by ID: GET /bookings/:id
   Authorization: Bearer Token
-  Response: Booking object
+  Response: Booking object with "nights" (date, day_type, name, point_cost, discount_points, rule_id)
+
+Point Pools:
+- Get My Pool: GET /pools/mine
+  Authorization: Bearer Token
+  Response: Pool object with "members" (name, email, available_points) and total "available_points"
+
+- Create Pool: POST /pools
+  Authorization: Bearer Token
+  Body: { "name": "string" }
+  Response: Pool object with "join_code"
+
+- Join Pool: POST /pools/join
+  Authorization: Bearer Token
+  Body: { "join_code": "string" }
+  Response: Pool object
+
+- Leave Pool: POST /pools/leave
+  Authorization: Bearer Token
+
+- Set Share Limit: PUT /pools/share-limit
+  Authorization: Bearer Token
+  Body: { "max_points": number }
+  Response: Pool object with "share_limits"
+  Note: other members can charge at most max_points of your points per booking, 0 (default) means none
 */
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/config"
+	"hotel-point-app/internal/repositories"
+	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/database"
+	"hotel-point-app/pkg/ical"
+)
+
+const usage = `Usage: dates <command> [flags]
+
+Commands:
+  import    Create or update special dates from an iCalendar (.ics) file`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "import":
+		runImport(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("file", "", "iCalendar (.ics) file to import")
+	ruleType := flags.String("type", "holiday", "Date type of the imported dates: regular, weekend or holiday")
+	pointCost := flags.Int("point-cost", 0, "Point cost of each imported date")
+	apply := flags.Bool("apply", false, "Save the changes instead of only previewing them")
+	flags.Parse(args)
+
+	if *file == "" {
+		log.Fatal("-file is required")
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("Failed to open calendar: %v", err)
+	}
+	defer input.Close()
+
+	events, err := ical.Parse(input)
+	if err != nil {
+		log.Fatalf("Invalid iCalendar file: %v", err)
+	}
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize configuration
+	cfg := config.NewConfig()
+
+	// Initialize MongoDB connection
+	db, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	dateRepo := repositories.NewDateRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	settingsRepo := repositories.NewSettingsRepository(db)
+	settingsService := services.NewSettingsService(settingsRepo)
+	dateService := services.NewDateService(dateRepo, txManager, settingsService)
+
+	// Perubahan dari CLI dicatat tanpa admin
+	result, err := dateService.ImportCalendar(events, *ruleType, *pointCost, !*apply, primitive.NilObjectID)
+	if err != nil {
+		log.Fatalf("Failed to import calendar: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		log.Fatalf("Failed to write result: %v", err)
+	}
+
+	if !result.Applied {
+		log.Printf("Preview: %d to create, %d to update, %d unchanged. Run again with -apply to save", result.Created, result.Updated, result.Unchanged)
+		return
+	}
+
+	log.Printf("Calendar imported: %d created, %d updated, %d unchanged", result.Created, result.Updated, result.Unchanged)
+}
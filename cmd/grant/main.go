@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"hotel-point-app/internal/config"
+	"hotel-point-app/internal/repositories"
+	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/database"
+)
+
+func main() {
+	periodKey := flag.String("period", "", "Grant period, e.g. 2026 (yearly), 2026-Q2 (quarterly) or 2026-05 (monthly). Defaults to the current period")
+	flag.Parse()
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize configuration
+	cfg := config.NewConfig()
+
+	// Initialize MongoDB connection
+	db, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	userRepo := repositories.NewUserRepository(db)
+	lotRepo := repositories.NewPointLotRepository(db)
+	tierRepo := repositories.NewTierRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	tierService := services.NewTierService(tierRepo, userRepo)
+	grantService := services.NewGrantService(userRepo, lotRepo, txManager, tierService, cfg.Grant.Points, cfg.Grant.Period, cfg.Points.ExpiryYears, cfg.Grant.ProrateRounding)
+
+	period := grantService.CurrentPeriod(time.Now())
+	if *periodKey != "" {
+		period, err = grantService.ParsePeriod(*periodKey)
+		if err != nil {
+			log.Fatalf("Invalid period: %v", err)
+		}
+	}
+
+	log.Printf("Granting points for period %s...", period.Key)
+
+	result, err := grantService.RunGrants(period)
+	if err != nil {
+		log.Fatalf("Failed to grant points: %v", err)
+	}
+
+	log.Printf("Point grant %s completed: %d granted, %d skipped, %d failed", result.Period, result.Granted, result.Skipped, result.Failed)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"hotel-point-app/internal/config"
+	"hotel-point-app/internal/repositories"
+	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/database"
+)
+
+const usage = `Usage: migrate <command> [flags]
+
+Commands:
+  booking-times    Move booking check-in/check-out to each hotel's local check-in/check-out times`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "booking-times":
+		runBookingTimes(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func runBookingTimes(args []string) {
+	flags := flag.NewFlagSet("booking-times", flag.ExitOnError)
+	apply := flags.Bool("apply", false, "Save the changes instead of only previewing them")
+	flags.Parse(args)
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize configuration
+	cfg := config.NewConfig()
+
+	// Initialize MongoDB connection
+	db, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	bookingRepo := repositories.NewBookingRepository(db)
+	hotelRepo := repositories.NewHotelRepository(db)
+	migrationService := services.NewMigrationService(bookingRepo, hotelRepo)
+
+	report, err := migrationService.NormalizeBookingTimes(!*apply)
+	if err != nil {
+		log.Fatalf("Failed to normalize booking times: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	if !report.Applied {
+		log.Printf("Preview: %d of %d bookings to change. Run again with -apply to save", report.Changed, report.BookingsChecked)
+		return
+	}
+
+	log.Printf("Booking times normalized: %d of %d bookings changed", report.Changed, report.BookingsChecked)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"hotel-point-app/internal/config"
+	"hotel-point-app/internal/repositories"
+	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/database"
+)
+
+func main() {
+	format := flag.String("format", "json", "Report format: json or csv")
+	output := flag.String("output", "", "Write the report to this file instead of stdout")
+	includeAll := flag.Bool("all", false, "Include users without drift in the report")
+	repair := flag.Bool("repair", false, "Write corrective adjustment transactions for every drift")
+	dryRun := flag.Bool("dry-run", false, "With -repair, report what would be written without writing it")
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("Invalid format %q, use json or csv", *format)
+	}
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	// Initialize configuration
+	cfg := config.NewConfig()
+
+	// Initialize MongoDB connection
+	db, err := database.NewMongoDB(cfg.MongoDB.URI, cfg.MongoDB.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+
+	userRepo := repositories.NewUserRepository(db)
+	txManager := repositories.NewTransactionManager(db)
+	reconcileService := services.NewReconcileService(userRepo, txManager)
+
+	var report *services.ReconcileReport
+	if *repair {
+		report, err = reconcileService.Repair(*dryRun)
+	} else {
+		report, err = reconcileService.Reconcile(*includeAll)
+	}
+	if err != nil {
+		log.Fatalf("Failed to reconcile point ledger: %v", err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	if *format == "csv" {
+		err = report.WriteCSV(out)
+	} else {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		log.Fatalf("Failed to write report: %v", err)
+	}
+
+	log.Printf("Checked %d users, %d with drift (total %d), %d repaired", report.UsersChecked, report.UsersWithDrift, report.TotalDrift, report.Repaired)
+}
@@ -91,6 +91,7 @@ func seedHotels(db *mongo.Database) error {
 			Address:     "Jl. MH Thamrin No. 1",
 			City:        "Jakarta",
 			Image:       "https://example.com/grand-hotel.jpg",
+			TimeZone:    "Asia/Jakarta",
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		},
@@ -101,6 +102,7 @@ func seedHotels(db *mongo.Database) error {
 			Address:     "Jl. Pantai Kuta No. 88",
 			City:        "Bali",
 			Image:       "https://example.com/beach-resort.jpg",
+			TimeZone:    "Asia/Makassar",
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		},
@@ -17,6 +17,31 @@ type Config struct {
 		Secret      string
 		ExpiryHours int
 	}
+	Grant struct {
+		Points           int    // Jumlah point per tahun untuk user yang tidak masuk tier mana pun
+		Period           string // "yearly", "quarterly", atau "monthly"
+		SchedulerEnabled bool
+		IntervalMinutes  int
+		ProrateRounding  string // Pembulatan grant pro-rata: "floor", "ceil", atau "nearest"
+	}
+	Points struct {
+		ExpiryYears           int // Point kedaluwarsa di akhir tahun grant + ExpiryYears
+		ExpiryIntervalMinutes int
+		// YearEndIntervalMinutes adalah interval pengecekan tahun point yang perlu ditutup
+		YearEndIntervalMinutes int
+		// YearEndFirstYear adalah tahun point pertama yang ditutup otomatis, 0 berarti penutupan
+		// otomatis tidak berjalan sampai admin menentukannya setelah meninjau preview
+		YearEndFirstYear int
+	}
+	Booking struct {
+		// ApprovalRequired membuat booking baru berstatus pending dan hanya menahan point
+		ApprovalRequired    bool
+		HoldHours           int // Lama point ditahan sebelum booking pending kedaluwarsa
+		HoldIntervalMinutes int
+		// QuoteSecret menandatangani quote harga, harus berbeda dari JWT secret
+		QuoteSecret  string
+		QuoteMinutes int // Lama quote harga berlaku
+	}
 }
 
 func NewConfig() *Config {
@@ -33,6 +58,28 @@ func NewConfig() *Config {
 	cfg.JWT.Secret = getEnv("JWT_SECRET", "Fr3eP@le5t1n3!!!")
 	cfg.JWT.ExpiryHours, _ = strconv.Atoi(getEnv("JWT_EXPIRY_HOURS", "24"))
 
+	// Point grant configuration
+	cfg.Grant.Points, _ = strconv.Atoi(getEnv("GRANT_POINTS", "24"))
+	cfg.Grant.Period = getEnv("GRANT_PERIOD", "yearly")
+	cfg.Grant.SchedulerEnabled = getEnv("GRANT_SCHEDULER_ENABLED", "true") == "true"
+	cfg.Grant.IntervalMinutes, _ = strconv.Atoi(getEnv("GRANT_INTERVAL_MINUTES", "60"))
+	cfg.Grant.ProrateRounding = getEnv("GRANT_PRORATE_ROUNDING", "floor")
+
+	// Point expiry configuration
+	cfg.Points.ExpiryYears, _ = strconv.Atoi(getEnv("POINT_EXPIRY_YEARS", "1"))
+	cfg.Points.ExpiryIntervalMinutes, _ = strconv.Atoi(getEnv("POINT_EXPIRY_INTERVAL_MINUTES", "60"))
+	cfg.Points.YearEndIntervalMinutes, _ = strconv.Atoi(getEnv("POINT_YEAR_END_INTERVAL_MINUTES", "60"))
+	cfg.Points.YearEndFirstYear, _ = strconv.Atoi(getEnv("POINT_YEAR_END_FIRST_YEAR", "0"))
+
+	// Booking approval configuration
+	cfg.Booking.ApprovalRequired = getEnv("BOOKING_APPROVAL_REQUIRED", "false") == "true"
+	cfg.Booking.HoldHours, _ = strconv.Atoi(getEnv("BOOKING_HOLD_HOURS", "48"))
+	cfg.Booking.HoldIntervalMinutes, _ = strconv.Atoi(getEnv("BOOKING_HOLD_INTERVAL_MINUTES", "15"))
+
+	// Booking quote configuration
+	cfg.Booking.QuoteSecret = getEnv("BOOKING_QUOTE_SECRET", cfg.JWT.Secret+"#quote")
+	cfg.Booking.QuoteMinutes, _ = strconv.Atoi(getEnv("BOOKING_QUOTE_MINUTES", "15"))
+
 	return cfg
 }
 
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,20 +11,45 @@ import (
 
 	"hotel-point-app/internal/models"
 	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/ical"
 	"hotel-point-app/pkg/utils"
 )
 
 // AdminHandler menangani operasi terkait admin
 type AdminHandler struct {
-	hotelService services.HotelService
-	dateService  services.DateService
+	hotelService     services.HotelService
+	dateService      services.DateService
+	pricingService   services.PricingService
+	reconcileService services.ReconcileService
+	settingsService  services.SettingsService
+	pointService     services.PointService
+	yearEndService   services.YearEndService
+	tierService      services.TierService
+	grantService     services.GrantService
 }
 
 // NewAdminHandler membuat handler baru untuk admin
-func NewAdminHandler(hotelService services.HotelService, dateService services.DateService) *AdminHandler {
+func NewAdminHandler(
+	hotelService services.HotelService,
+	dateService services.DateService,
+	pricingService services.PricingService,
+	reconcileService services.ReconcileService,
+	settingsService services.SettingsService,
+	pointService services.PointService,
+	yearEndService services.YearEndService,
+	tierService services.TierService,
+	grantService services.GrantService,
+) *AdminHandler {
 	return &AdminHandler{
-		hotelService: hotelService,
-		dateService:  dateService,
+		hotelService:     hotelService,
+		dateService:      dateService,
+		pricingService:   pricingService,
+		reconcileService: reconcileService,
+		settingsService:  settingsService,
+		pointService:     pointService,
+		yearEndService:   yearEndService,
+		tierService:      tierService,
+		grantService:     grantService,
 	}
 }
 
@@ -35,6 +62,14 @@ type CreateHotelRequest struct {
 	Address     string `json:"address" binding:"required" example:"Jl. MH Thamrin No. 1"`
 	City        string `json:"city" binding:"required" example:"Jakarta"`
 	Image       string `json:"image" example:"https://example.com/hotel.jpg"`
+	// PointMultiplier adalah pengali biaya point untuk semua kamar hotel, default 1
+	PointMultiplier float64 `json:"point_multiplier" binding:"omitempty,gt=0,lte=10" example:"1.5"`
+	// WeekendDays menggantikan hari weekend pengaturan untuk hotel ini (0 = Minggu ... 6 = Sabtu)
+	WeekendDays []int `json:"weekend_days" binding:"omitempty,dive,min=0,max=6" example:"5,6"`
+	// TimeZone adalah zona waktu IANA hotel, default Asia/Jakarta (WIB)
+	TimeZone     string `json:"time_zone" example:"Asia/Makassar"`
+	CheckInTime  string `json:"check_in_time" example:"14:00"`  // Format HH:MM waktu lokal, default 14:00
+	CheckOutTime string `json:"check_out_time" example:"12:00"` // Format HH:MM waktu lokal, default 12:00
 }
 
 // CreateHotel godoc
@@ -68,10 +103,23 @@ func (h *AdminHandler) CreateHotel(c *gin.Context) {
 		Image:       req.Image,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
+
+		PointMultiplier: req.PointMultiplier,
+		WeekendDays:     toWeekdays(req.WeekendDays),
+		TimeZone:        req.TimeZone,
+		CheckInTime:     req.CheckInTime,
+		CheckOutTime:    req.CheckOutTime,
+	}
+	if hotel.PointMultiplier == 0 {
+		hotel.PointMultiplier = 1
 	}
 
 	// Create hotel
 	if err := h.hotelService.CreateHotel(hotel); err != nil {
+		if isHotelScheduleError(err) {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -86,6 +134,14 @@ type UpdateHotelRequest struct {
 	Address     string `json:"address" example:"Updated address"`
 	City        string `json:"city" example:"Updated city"`
 	Image       string `json:"image" example:"https://example.com/new-image.jpg"`
+	// PointMultiplier adalah pengali biaya point untuk semua kamar hotel
+	PointMultiplier float64 `json:"point_multiplier" binding:"omitempty,gt=0,lte=10" example:"1.5"`
+	// WeekendDays menggantikan hari weekend pengaturan untuk hotel ini, [] kembali ikut pengaturan
+	WeekendDays []int `json:"weekend_days" binding:"omitempty,dive,min=0,max=6" example:"5,6"`
+	// TimeZone adalah zona waktu IANA hotel
+	TimeZone     string `json:"time_zone" example:"Asia/Makassar"`
+	CheckInTime  string `json:"check_in_time" example:"14:00"`  // Format HH:MM waktu lokal
+	CheckOutTime string `json:"check_out_time" example:"12:00"` // Format HH:MM waktu lokal
 }
 
 // UpdateHotel godoc
@@ -141,10 +197,29 @@ func (h *AdminHandler) UpdateHotel(c *gin.Context) {
 	if req.Image != "" {
 		hotel.Image = req.Image
 	}
+	if req.PointMultiplier > 0 {
+		hotel.PointMultiplier = req.PointMultiplier
+	}
+	if req.WeekendDays != nil {
+		hotel.WeekendDays = toWeekdays(req.WeekendDays)
+	}
+	if req.TimeZone != "" {
+		hotel.TimeZone = req.TimeZone
+	}
+	if req.CheckInTime != "" {
+		hotel.CheckInTime = req.CheckInTime
+	}
+	if req.CheckOutTime != "" {
+		hotel.CheckOutTime = req.CheckOutTime
+	}
 	hotel.UpdatedAt = time.Now()
 
 	// Update hotel
 	if err := h.hotelService.UpdateHotel(hotel); err != nil {
+		if isHotelScheduleError(err) {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -152,6 +227,94 @@ func (h *AdminHandler) UpdateHotel(c *gin.Context) {
 	utils.SendSuccessResponse(c, http.StatusOK, "Hotel updated successfully", nil)
 }
 
+// LastMinuteDiscountRequest adalah request body untuk mengatur potongan last-minute hotel
+type LastMinuteDiscountRequest struct {
+	Enabled        *bool `json:"enabled" binding:"required" example:"true"`
+	WithinDays     int   `json:"within_days" binding:"min=0" example:"3"`              // Malam dalam sekian hari dari hari ini
+	OccupancyBelow int   `json:"occupancy_below" binding:"min=0,max=100" example:"50"` // Persen okupansi hotel
+	PercentOff     int   `json:"percent_off" binding:"min=0,max=100" example:"30"`     // Persen potongan per malam, dibulatkan ke bawah
+}
+
+// SetHotelLastMinuteDiscount godoc
+// @Summary     Set hotel last-minute discount
+// @Description Lower the point cost of nights within within_days days of today while the hotel's occupancy for that night is below occupancy_below percent. Set enabled to false to turn the discount off (admin only)
+// @Tags        admin-hotels
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Hotel ID"
+// @Param       request body LastMinuteDiscountRequest true "Last-minute discount"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/hotels/{id}/last-minute-discount [put]
+func (h *AdminHandler) SetHotelLastMinuteDiscount(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid hotel ID format")
+		return
+	}
+
+	var req LastMinuteDiscountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var rule *models.LastMinuteDiscount
+	if *req.Enabled {
+		rule = &models.LastMinuteDiscount{
+			WithinDays:     req.WithinDays,
+			OccupancyBelow: req.OccupancyBelow,
+			PercentOff:     req.PercentOff,
+		}
+	}
+
+	hotel, err := h.hotelService.SetLastMinuteDiscount(id, rule)
+	if err != nil {
+		switch err.Error() {
+		case "hotel not found":
+			utils.SendErrorResponse(c, http.StatusNotFound, "Hotel not found")
+		case "within_days must be at least 1",
+			"occupancy_below must be between 1 and 100",
+			"percent_off must be between 1 and 100":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Last-minute discount updated successfully", hotel)
+}
+
+// isHotelScheduleError memeriksa apakah error berasal dari validasi zona waktu atau jam check-in/check-out hotel
+func isHotelScheduleError(err error) bool {
+	switch err.Error() {
+	case "invalid time zone",
+		"invalid check-in time, use HH:MM",
+		"invalid check-out time, use HH:MM":
+		return true
+	}
+	return false
+}
+
+// toWeekdays mengubah daftar hari dari request menjadi time.Weekday
+func toWeekdays(days []int) []time.Weekday {
+	if len(days) == 0 {
+		return nil
+	}
+
+	weekdays := make([]time.Weekday, 0, len(days))
+	for _, day := range days {
+		weekdays = append(weekdays, time.Weekday(day))
+	}
+	return weekdays
+}
+
 // DeleteHotel godoc
 // @Summary     Delete a hotel
 // @Description Delete a hotel (admin only)
@@ -200,6 +363,8 @@ type CreateRoomRequest struct {
 	Description string `json:"description" binding:"required" example:"Kamar mewah dengan pemandangan kota"`
 	Capacity    int    `json:"capacity" binding:"required,min=1" example:"2"`
 	Image       string `json:"image" example:"https://example.com/room.jpg"`
+	// PointMultiplier adalah pengali biaya point kamar di atas pengali hotel, default 1
+	PointMultiplier float64 `json:"point_multiplier" binding:"omitempty,gt=0,lte=10" example:"2"`
 }
 
 // CreateRoom godoc
@@ -239,6 +404,11 @@ func (h *AdminHandler) CreateRoom(c *gin.Context) {
 		Description: req.Description,
 		Capacity:    req.Capacity,
 		Image:       req.Image,
+
+		PointMultiplier: req.PointMultiplier,
+	}
+	if room.PointMultiplier == 0 {
+		room.PointMultiplier = 1
 	}
 
 	// Create room
@@ -261,6 +431,8 @@ type UpdateRoomRequest struct {
 	Description string `json:"description" example:"Updated room description"`
 	Capacity    int    `json:"capacity" example:"4"`
 	Image       string `json:"image" example:"https://example.com/new-room.jpg"`
+	// PointMultiplier adalah pengali biaya point kamar di atas pengali hotel
+	PointMultiplier float64 `json:"point_multiplier" binding:"omitempty,gt=0,lte=10" example:"2"`
 }
 
 // UpdateRoom godoc
@@ -321,6 +493,9 @@ func (h *AdminHandler) UpdateRoom(c *gin.Context) {
 	if req.Image != "" {
 		room.Image = req.Image
 	}
+	if req.PointMultiplier > 0 {
+		room.PointMultiplier = req.PointMultiplier
+	}
 
 	// Update room
 	if err := h.hotelService.UpdateRoom(room); err != nil {
@@ -524,15 +699,15 @@ func (h *AdminHandler) GetRoomAvailability(c *gin.Context) {
 
 // SpecialDateRequest adalah request body untuk mengatur tanggal khusus
 type SpecialDateRequest struct {
-	Date      string `json:"date" binding:"required" example:"2025-12-25"` // Format YYYY-MM-DD
-	Type      string `json:"type" binding:"required" example:"holiday"`    // "regular", "weekend", "holiday"
-	PointCost int    `json:"point_cost" binding:"required,min=1,max=3" example:"3"`
+	Date      string `json:"date" binding:"required" example:"2025-12-25"`    // Format YYYY-MM-DD
+	Type      string `json:"type" binding:"required" example:"holiday"`       // "regular", "weekend", "holiday"
+	PointCost int    `json:"point_cost" binding:"required,min=1" example:"3"` // Paling tinggi max_point_cost pada pengaturan
 	Name      string `json:"name" example:"Hari Natal"`
 }
 
 // SetSpecialDate godoc
 // @Summary     Set special date
-// @Description Set a special date with custom point cost (admin only)
+// @Description Set a special date with custom point cost. Every change is recorded as a new version of the rule (admin only)
 // @Tags        admin-dates
 // @Accept      json
 // @Produce     json
@@ -573,8 +748,14 @@ func (h *AdminHandler) SetSpecialDate(c *gin.Context) {
 		Name:      req.Name,
 	}
 
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
 	// Set special date
-	if err := h.dateService.SetSpecialDate(rule); err != nil {
+	if err := h.dateService.SetSpecialDate(rule, adminID); err != nil {
+		if err.Error() == "point cost exceeds the maximum point cost" {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -637,7 +818,7 @@ func (h *AdminHandler) GetSpecialDates(c *gin.Context) {
 
 // DeleteSpecialDate godoc
 // @Summary     Delete special date
-// @Description Delete a special date (admin only)
+// @Description Delete a special date. The rule's history is kept and ends with a deleted version (admin only)
 // @Tags        admin-dates
 // @Produce     json
 // @Security    BearerAuth
@@ -646,6 +827,7 @@ func (h *AdminHandler) GetSpecialDates(c *gin.Context) {
 // @Failure     400 {object} utils.APIErrorResponse
 // @Failure     401 {object} utils.APIErrorResponse
 // @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
 // @Failure     500 {object} utils.APIErrorResponse
 // @Router      /admin/dates/special/{id} [delete]
 func (h *AdminHandler) DeleteSpecialDate(c *gin.Context) {
@@ -656,11 +838,1260 @@ func (h *AdminHandler) DeleteSpecialDate(c *gin.Context) {
 		return
 	}
 
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
 	// Delete special date
-	if err := h.dateService.DeleteSpecialDate(id); err != nil {
+	if err := h.dateService.DeleteSpecialDate(id, adminID); err != nil {
+		if err.Error() == "date rule not found" {
+			utils.SendErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
 		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	utils.SendSuccessResponse(c, http.StatusOK, "Special date deleted successfully", nil)
 }
+
+// SpecialDateRangeRequest adalah rentang tanggal inklusif
+type SpecialDateRangeRequest struct {
+	From string `json:"from" binding:"required" example:"2025-12-20"` // Format YYYY-MM-DD
+	To   string `json:"to" binding:"required" example:"2026-01-03"`   // Format YYYY-MM-DD
+}
+
+// BulkSpecialDateRequest memilih tanggal lewat daftar dates, rentang ranges, atau keduanya
+type BulkSpecialDateRequest struct {
+	Dates     []string                  `json:"dates" example:"2025-12-25"` // Format YYYY-MM-DD
+	Ranges    []SpecialDateRangeRequest `json:"ranges"`
+	Type      string                    `json:"type" binding:"required" example:"holiday"`       // "regular", "weekend", "holiday"
+	PointCost int                       `json:"point_cost" binding:"required,min=1" example:"3"` // Paling tinggi max_point_cost pada pengaturan
+	Name      string                    `json:"name" example:"Libur Akhir Tahun"`
+}
+
+// BulkDeleteSpecialDateRequest memilih tanggal lewat daftar dates, rentang ranges, atau keduanya
+type BulkDeleteSpecialDateRequest struct {
+	Dates  []string                  `json:"dates" example:"2025-12-25"` // Format YYYY-MM-DD
+	Ranges []SpecialDateRangeRequest `json:"ranges"`
+}
+
+// BulkSetSpecialDates godoc
+// @Summary     Bulk set special dates
+// @Description Create or update the special date of every selected day with one type, name and point cost. Days are selected by a list of dates, inclusive ranges, or both, at most 366 days per request. All changes are saved in one transaction and every day is reported as create, update or unchanged (admin only)
+// @Tags        admin-dates
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body BulkSpecialDateRequest true "Selected dates and special date information"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/special/bulk [post]
+func (h *AdminHandler) BulkSetSpecialDates(c *gin.Context) {
+	var req BulkSpecialDateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dates, errMsg := parseSpecialDateSelection(req.Dates, req.Ranges)
+	if errMsg != "" {
+		utils.SendErrorResponse(c, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	result, err := h.dateService.BulkSetSpecialDates(dates, req.Type, req.Name, req.PointCost, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "invalid type, must be: regular, weekend, or holiday",
+			"point cost must be at least 1",
+			"point cost exceeds the maximum point cost",
+			"no dates selected",
+			"cannot change more than 366 dates at once":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Special dates set successfully", result)
+}
+
+// BulkDeleteSpecialDates godoc
+// @Summary     Bulk delete special dates
+// @Description Delete the special date of every selected day. Days are selected by a list of dates, inclusive ranges, or both, at most 366 days per request. All deletions are saved in one transaction and every day is reported as delete or not_found (admin only)
+// @Tags        admin-dates
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body BulkDeleteSpecialDateRequest true "Selected dates"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/special/bulk-delete [post]
+func (h *AdminHandler) BulkDeleteSpecialDates(c *gin.Context) {
+	var req BulkDeleteSpecialDateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dates, errMsg := parseSpecialDateSelection(req.Dates, req.Ranges)
+	if errMsg != "" {
+		utils.SendErrorResponse(c, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	result, err := h.dateService.BulkDeleteSpecialDates(dates, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "no dates selected", "cannot change more than 366 dates at once":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Special dates deleted successfully", result)
+}
+
+// parseSpecialDateSelection menggabungkan daftar tanggal dan rentang menjadi satu daftar tanggal.
+// Pesan error dikembalikan untuk format tanggal atau rentang yang tidak valid.
+func parseSpecialDateSelection(dateStrs []string, ranges []SpecialDateRangeRequest) ([]time.Time, string) {
+	// Tanggal unik dihitung selama diuraikan sehingga permintaan dengan banyak rentang
+	// ditolak begitu melewati batas, bukan setelah semua tanggalnya dibuat
+	if len(dateStrs)+len(ranges) > 366 {
+		return nil, "cannot change more than 366 dates at once"
+	}
+
+	var dates []time.Time
+	seen := make(map[time.Time]bool)
+	add := func(date time.Time) bool {
+		if seen[date] {
+			return true
+		}
+		if len(dates) == 366 {
+			return false
+		}
+		seen[date] = true
+		dates = append(dates, date)
+		return true
+	}
+
+	for _, dateStr := range dateStrs {
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			return nil, "Invalid date " + dateStr + ", use YYYY-MM-DD"
+		}
+		if !add(date) {
+			return nil, "cannot change more than 366 dates at once"
+		}
+	}
+
+	for _, r := range ranges {
+		from, err := time.Parse("2006-01-02", r.From)
+		if err != nil {
+			return nil, "Invalid range from " + r.From + ", use YYYY-MM-DD"
+		}
+		to, err := time.Parse("2006-01-02", r.To)
+		if err != nil {
+			return nil, "Invalid range to " + r.To + ", use YYYY-MM-DD"
+		}
+		if from.After(to) {
+			return nil, "Range from cannot be after to"
+		}
+		// Rentang yang terlalu panjang ditolak sebelum diuraikan per tanggal
+		if to.Sub(from) >= 366*24*time.Hour {
+			return nil, "cannot change more than 366 dates at once"
+		}
+
+		for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+			if !add(d) {
+				return nil, "cannot change more than 366 dates at once"
+			}
+		}
+	}
+
+	return dates, ""
+}
+
+// GetSpecialDateHistory godoc
+// @Summary     Get special date history
+// @Description Get every version of a special date, oldest first, with the admin who made each change and when. Deleted rules keep their history (admin only)
+// @Tags        admin-dates
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Date Rule ID"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/special/{id}/history [get]
+func (h *AdminHandler) GetSpecialDateHistory(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid date rule ID format")
+		return
+	}
+
+	versions, err := h.dateService.GetDateRuleHistory(id)
+	if err != nil {
+		if err.Error() == "date rule not found" {
+			utils.SendErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Special date history retrieved successfully", gin.H{"versions": versions})
+}
+
+// ImportCalendar godoc
+// @Summary     Import special dates from an iCalendar file
+// @Description Read every event of an .ics file (e.g. a public holiday calendar) and create or update the special date of each day it covers with the chosen type and point cost. A calendar may contain at most 1000 events and cover at most 366 days. Without apply=true only a preview of the created, updated and unchanged dates is returned (admin only)
+// @Tags        admin-dates
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    BearerAuth
+// @Param       file formData file true "iCalendar (.ics) file"
+// @Param       type formData string false "Date type: regular, weekend or holiday (default holiday)"
+// @Param       point_cost formData int true "Point cost of each imported date"
+// @Param       apply formData bool false "Save the changes instead of only previewing them"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/import [post]
+func (h *AdminHandler) ImportCalendar(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "file is required")
+		return
+	}
+
+	ruleType := c.DefaultPostForm("type", "holiday")
+
+	pointCost, err := strconv.Atoi(c.PostForm("point_cost"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "point_cost must be a number")
+		return
+	}
+
+	apply, err := strconv.ParseBool(c.DefaultPostForm("apply", "false"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "apply must be true or false")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	events, err := ical.Parse(file)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid iCalendar file: "+err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	result, err := h.dateService.ImportCalendar(events, ruleType, pointCost, !apply, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "invalid type, must be: regular, weekend, or holiday",
+			"point cost must be at least 1",
+			"point cost exceeds the maximum point cost",
+			"calendar contains no events",
+			"calendar covers more than 366 dates":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	message := "Calendar import preview generated successfully"
+	if apply {
+		message = "Calendar imported successfully"
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, message, result)
+}
+
+// RecurringDateRuleRequest adalah request body untuk membuat atau mengubah aturan tanggal berulang
+type RecurringDateRuleRequest struct {
+	Name       string `json:"name" binding:"required" example:"Hari Kemerdekaan"`
+	Type       string `json:"type" binding:"required,oneof=regular weekend holiday" example:"holiday"`
+	PointCost  int    `json:"point_cost" binding:"required,min=1" example:"3"`
+	Recurrence string `json:"recurrence" binding:"required,oneof=yearly nth_weekday range" example:"yearly"`
+	Month      int    `json:"month" binding:"min=0,max=12" example:"8"`     // 0 untuk nth_weekday setiap bulan
+	Day        int    `json:"day" binding:"min=0,max=31" example:"17"`      // Untuk yearly dan range
+	Weekday    int    `json:"weekday" binding:"min=0,max=6" example:"1"`    // 0 = Minggu, untuk nth_weekday
+	Week       int    `json:"week" binding:"min=-1,max=5" example:"2"`      // -1 untuk minggu terakhir, untuk nth_weekday
+	EndMonth   int    `json:"end_month" binding:"min=0,max=12" example:"1"` // Untuk range
+	EndDay     int    `json:"end_day" binding:"min=0,max=31" example:"5"`   // Untuk range
+	ValidFrom  string `json:"valid_from" example:"2025-01-01"`              // Opsional, format YYYY-MM-DD
+	ValidUntil string `json:"valid_until" example:"2030-12-31"`             // Opsional, format YYYY-MM-DD
+	Priority   int    `json:"priority" example:"0"`
+}
+
+func (req *RecurringDateRuleRequest) toModel(id primitive.ObjectID) (*models.RecurringDateRule, error) {
+	rule := &models.RecurringDateRule{
+		ID:         id,
+		Name:       req.Name,
+		Type:       req.Type,
+		PointCost:  req.PointCost,
+		Recurrence: req.Recurrence,
+		Month:      req.Month,
+		Day:        req.Day,
+		Weekday:    time.Weekday(req.Weekday),
+		Week:       req.Week,
+		EndMonth:   req.EndMonth,
+		EndDay:     req.EndDay,
+		Priority:   req.Priority,
+	}
+
+	if req.ValidFrom != "" {
+		date, err := time.Parse("2006-01-02", req.ValidFrom)
+		if err != nil {
+			return nil, errors.New("Invalid valid_from format, use YYYY-MM-DD")
+		}
+		rule.ValidFrom = &date
+	}
+	if req.ValidUntil != "" {
+		date, err := time.Parse("2006-01-02", req.ValidUntil)
+		if err != nil {
+			return nil, errors.New("Invalid valid_until format, use YYYY-MM-DD")
+		}
+		rule.ValidUntil = &date
+	}
+
+	return rule, nil
+}
+
+// recurringRuleErrorStatus memetakan error validasi aturan berulang ke status HTTP
+func recurringRuleErrorStatus(err error) int {
+	switch err.Error() {
+	case "recurring date rule not found":
+		return http.StatusNotFound
+	case "rule name cannot be empty",
+		"invalid type, must be: regular, weekend, or holiday",
+		"point cost must be at least 1",
+		"point cost exceeds the maximum point cost",
+		"invalid month or day",
+		"weekday must be between 0 (Sunday) and 6 (Saturday)",
+		"week must be between 1 and 5, or -1 for the last week",
+		"invalid recurrence, must be: yearly, nth_weekday, or range",
+		"valid_from cannot be after valid_until":
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// GetRecurringDates godoc
+// @Summary     Get recurring date rules
+// @Description Get all recurring date rules (yearly dates, nth weekdays and seasonal ranges) (admin only)
+// @Tags        admin-dates
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/recurring [get]
+func (h *AdminHandler) GetRecurringDates(c *gin.Context) {
+	rules, err := h.dateService.GetRecurringRules()
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Recurring date rules retrieved successfully", gin.H{"recurring_dates": rules})
+}
+
+// CreateRecurringDate godoc
+// @Summary     Create recurring date rule
+// @Description Create a rule that prices matching days without one special date per day: yearly (month/day), nth_weekday (weekday, week, month or 0 for every month) or range (month/day to end_month/end_day, may wrap the year end). Special dates take precedence over recurring rules (admin only)
+// @Tags        admin-dates
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body RecurringDateRuleRequest true "Recurring Date Rule"
+// @Success     201 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/recurring [post]
+func (h *AdminHandler) CreateRecurringDate(c *gin.Context) {
+	var req RecurringDateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := req.toModel(primitive.NewObjectID())
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.dateService.CreateRecurringRule(rule); err != nil {
+		utils.SendErrorResponse(c, recurringRuleErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, "Recurring date rule created successfully", rule)
+}
+
+// UpdateRecurringDate godoc
+// @Summary     Update recurring date rule
+// @Description Update a recurring date rule (admin only)
+// @Tags        admin-dates
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Recurring Date Rule ID"
+// @Param       request body RecurringDateRuleRequest true "Recurring Date Rule"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/recurring/{id} [put]
+func (h *AdminHandler) UpdateRecurringDate(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid recurring date rule ID format")
+		return
+	}
+
+	var req RecurringDateRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule, err := req.toModel(id)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.dateService.UpdateRecurringRule(rule); err != nil {
+		utils.SendErrorResponse(c, recurringRuleErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Recurring date rule updated successfully", rule)
+}
+
+// DeleteRecurringDate godoc
+// @Summary     Delete recurring date rule
+// @Description Delete a recurring date rule (admin only)
+// @Tags        admin-dates
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Recurring Date Rule ID"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/recurring/{id} [delete]
+func (h *AdminHandler) DeleteRecurringDate(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid recurring date rule ID format")
+		return
+	}
+
+	if err := h.dateService.DeleteRecurringRule(id); err != nil {
+		utils.SendErrorResponse(c, recurringRuleErrorStatus(err), err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Recurring date rule deleted successfully", nil)
+}
+
+// GetDateCalendar godoc
+// @Summary     Preview point calendar
+// @Description Expand special dates, recurring rules and weekday defaults into the point cost of every day in a range of at most 366 days. With hotel_id, the hotel's weekend days are used. With as_of, special dates are priced as they were at that moment; recurring rules and defaults always use their current values (admin only)
+// @Tags        admin-dates
+// @Produce     json
+// @Security    BearerAuth
+// @Param       from_date query string true "From Date (YYYY-MM-DD)" example:"2025-01-01"
+// @Param       to_date query string true "To Date (YYYY-MM-DD)" example:"2025-12-31"
+// @Param       hotel_id query string false "Hotel ID"
+// @Param       as_of query string false "Price special dates as of this moment (RFC3339)" example:"2025-06-01T00:00:00Z"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/dates/calendar [get]
+func (h *AdminHandler) GetDateCalendar(c *gin.Context) {
+	fromDateStr := c.Query("from_date")
+	toDateStr := c.Query("to_date")
+
+	if fromDateStr == "" || toDateStr == "" {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "from_date and to_date query parameters are required")
+		return
+	}
+
+	fromDate, err := time.Parse("2006-01-02", fromDateStr)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid from_date format, use YYYY-MM-DD")
+		return
+	}
+
+	toDate, err := time.Parse("2006-01-02", toDateStr)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid to_date format, use YYYY-MM-DD")
+		return
+	}
+
+	if fromDate.After(toDate) {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "from_date cannot be after to_date")
+		return
+	}
+
+	var hotel *models.Hotel
+	if hotelIDStr := c.Query("hotel_id"); hotelIDStr != "" {
+		hotelID, err := primitive.ObjectIDFromHex(hotelIDStr)
+		if err != nil {
+			utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid hotel ID format")
+			return
+		}
+
+		hotel, err = h.hotelService.GetHotelByID(hotelID)
+		if err != nil {
+			utils.SendErrorResponse(c, http.StatusNotFound, "Hotel not found")
+			return
+		}
+	}
+
+	var days []services.CalendarDay
+	if asOfStr := c.Query("as_of"); asOfStr != "" {
+		asOf, parseErr := time.Parse(time.RFC3339, asOfStr)
+		if parseErr != nil {
+			utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid as_of format, use RFC3339")
+			return
+		}
+		days, err = h.pricingService.GetCalendarAsOf(hotel, fromDate, toDate, asOf)
+	} else {
+		days, err = h.pricingService.GetCalendar(hotel, fromDate, toDate)
+	}
+	if err != nil {
+		if err.Error() == "date range cannot exceed 366 days" {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Calendar generated successfully", gin.H{"days": days})
+}
+
+// POINT LEDGER
+
+// ReconcilePoints godoc
+// @Summary     Reconcile point balances
+// @Description Compare each user's point balance with the sum of their point transactions (admin only)
+// @Tags        admin-points
+// @Produce     json
+// @Produce     text/csv
+// @Security    BearerAuth
+// @Param       format query string false "Report format (json or csv)" example:"json"
+// @Param       all query bool false "Include users without drift"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/points/reconcile [get]
+func (h *AdminHandler) ReconcilePoints(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid format, must be: json or csv")
+		return
+	}
+
+	report, err := h.reconcileService.Reconcile(c.Query("all") == "true")
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	h.sendReconcileReport(c, format, "Point ledger reconciled successfully", report)
+}
+
+// RepairPointsRequest adalah request body untuk memperbaiki drift ledger point
+type RepairPointsRequest struct {
+	DryRun bool `json:"dry_run" example:"true"`
+}
+
+// RepairPoints godoc
+// @Summary     Repair point ledger drift
+// @Description Write corrective adjustment transactions so each user's ledger matches their balance (admin only)
+// @Tags        admin-points
+// @Accept      json
+// @Produce     json
+// @Produce     text/csv
+// @Security    BearerAuth
+// @Param       format query string false "Report format (json or csv)" example:"json"
+// @Param       request body RepairPointsRequest false "Repair options"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/points/reconcile/repair [post]
+func (h *AdminHandler) RepairPoints(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid format, must be: json or csv")
+		return
+	}
+
+	var req RepairPointsRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	report, err := h.reconcileService.Repair(req.DryRun)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	message := "Point ledger repaired successfully"
+	if req.DryRun {
+		message = "Point ledger repair previewed successfully"
+	}
+
+	h.sendReconcileReport(c, format, message, report)
+}
+
+// AdjustPointsRequest adalah request body untuk adjustment point user
+type AdjustPointsRequest struct {
+	Amount        int    `json:"amount" binding:"required" example:"-2"`
+	ReasonCode    string `json:"reason_code" binding:"required,oneof=correction compensation goodwill policy other" example:"correction"`
+	Comment       string `json:"comment" binding:"required,max=500" example:"Duplicate booking deduction"`
+	AllowNegative bool   `json:"allow_negative" example:"false"`
+}
+
+// AdjustUserPoints godoc
+// @Summary     Adjust a user's points
+// @Description Credit (positive amount) or debit (negative amount) a user's points with a reason. Rejected if the available points (balance minus points held by pending bookings) would go negative unless allow_negative is set (admin only)
+// @Tags        admin-points
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "User ID"
+// @Param       request body AdjustPointsRequest true "Adjustment"
+// @Success     201 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/users/{id}/points/adjust [post]
+func (h *AdminHandler) AdjustUserPoints(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var req AdjustPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	adjustment, err := h.pointService.AdjustPoints(userID, adminID, services.AdjustmentRequest{
+		Amount:        req.Amount,
+		ReasonCode:    req.ReasonCode,
+		Comment:       req.Comment,
+		AllowNegative: req.AllowNegative,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "user not found":
+			utils.SendErrorResponse(c, http.StatusNotFound, err.Error())
+		case "adjustment amount cannot be zero", "invalid reason code", "comment is required", "adjustment would make available points negative":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, "Points adjusted successfully", adjustment)
+}
+
+// PreviewYearEnd godoc
+// @Summary     Preview year-end point forfeiture
+// @Description Show each user's projected carry-over and forfeiture under the current carry-over rule. Unused points are the user's ledger balance at the end of the year; the forfeit never exceeds the points still available, so points held by pending bookings are kept (admin only)
+// @Tags        admin-points
+// @Produce     json
+// @Security    BearerAuth
+// @Param       year query int false "Point year (default current year)" example:"2026"
+// @Param       all query bool false "Include users without forfeiture"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/points/year-end/preview [get]
+func (h *AdminHandler) PreviewYearEnd(c *gin.Context) {
+	year := time.Now().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 {
+			utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		year = parsed
+	}
+
+	preview, err := h.yearEndService.Preview(year, c.Query("all") == "true")
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Year-end preview generated successfully", preview)
+}
+
+// sendReconcileReport mengirim laporan rekonsiliasi sebagai JSON atau file CSV
+func (h *AdminHandler) sendReconcileReport(c *gin.Context, format, message string, report *services.ReconcileReport) {
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv")
+		c.Header("Content-Disposition", "attachment; filename=point-reconcile-"+report.GeneratedAt.Format("20060102-150405")+".csv")
+		c.Status(http.StatusOK)
+		if err := report.WriteCSV(c.Writer); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, message, report)
+}
+
+// TIER MANAGEMENT
+
+// TierRequest adalah request body untuk membuat atau mengubah tier
+type TierRequest struct {
+	Name              string   `json:"name" binding:"required" example:"Senior"`
+	Grades            []string `json:"grades" example:"G5,G6"` // Kosong berarti semua grade
+	MinYearsOfService int      `json:"min_years_of_service" binding:"min=0" example:"5"`
+	AnnualPoints      int      `json:"annual_points" binding:"min=0" example:"36"`
+	MaxActiveBookings int      `json:"max_active_bookings" binding:"min=0" example:"3"`   // 0 = tanpa batas
+	BookingWindowDays int      `json:"booking_window_days" binding:"min=0" example:"180"` // 0 = tanpa batas
+}
+
+func (req *TierRequest) toModel(id primitive.ObjectID) *models.Tier {
+	return &models.Tier{
+		ID:                id,
+		Name:              req.Name,
+		Grades:            req.Grades,
+		MinYearsOfService: req.MinYearsOfService,
+		AnnualPoints:      req.AnnualPoints,
+		MaxActiveBookings: req.MaxActiveBookings,
+		BookingWindowDays: req.BookingWindowDays,
+	}
+}
+
+// GetTiers godoc
+// @Summary     Get entitlement tiers
+// @Description Get all point entitlement tiers (admin only)
+// @Tags        admin-tiers
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/tiers [get]
+func (h *AdminHandler) GetTiers(c *gin.Context) {
+	tiers, err := h.tierService.GetTiers()
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Tiers retrieved successfully", gin.H{"tiers": tiers})
+}
+
+// CreateTier godoc
+// @Summary     Create an entitlement tier
+// @Description Create a tier defining yearly grant size, max active bookings and booking window for matching grades and years of service (admin only)
+// @Tags        admin-tiers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body TierRequest true "Tier Information"
+// @Success     201 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/tiers [post]
+func (h *AdminHandler) CreateTier(c *gin.Context) {
+	var req TierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tier := req.toModel(primitive.NewObjectID())
+	if err := h.tierService.CreateTier(tier); err != nil {
+		switch err.Error() {
+		case "tier name cannot be empty", "tier values cannot be negative":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, "Tier created successfully", tier)
+}
+
+// UpdateTier godoc
+// @Summary     Update an entitlement tier
+// @Description Update a point entitlement tier (admin only)
+// @Tags        admin-tiers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Tier ID"
+// @Param       request body TierRequest true "Tier Information"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/tiers/{id} [put]
+func (h *AdminHandler) UpdateTier(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid tier ID format")
+		return
+	}
+
+	var req TierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tier := req.toModel(id)
+	if err := h.tierService.UpdateTier(tier); err != nil {
+		switch err.Error() {
+		case "tier not found":
+			utils.SendErrorResponse(c, http.StatusNotFound, "Tier not found")
+		case "tier name cannot be empty", "tier values cannot be negative":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Tier updated successfully", tier)
+}
+
+// DeleteTier godoc
+// @Summary     Delete an entitlement tier
+// @Description Delete a point entitlement tier (admin only)
+// @Tags        admin-tiers
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Tier ID"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/tiers/{id} [delete]
+func (h *AdminHandler) DeleteTier(c *gin.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid tier ID format")
+		return
+	}
+
+	if err := h.tierService.DeleteTier(id); err != nil {
+		if err.Error() == "tier not found" {
+			utils.SendErrorResponse(c, http.StatusNotFound, "Tier not found")
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Tier deleted successfully", nil)
+}
+
+// SetUserGradeRequest adalah request body untuk mengubah grade user
+type SetUserGradeRequest struct {
+	Grade string `json:"grade" example:"G5"` // Kosong untuk menghapus grade
+}
+
+// SetUserGrade godoc
+// @Summary     Set a user's grade
+// @Description Set the employee grade used to resolve the user's entitlement tier (admin only)
+// @Tags        admin-tiers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "User ID"
+// @Param       request body SetUserGradeRequest true "Grade"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/users/{id}/grade [put]
+func (h *AdminHandler) SetUserGrade(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var req SetUserGradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.tierService.SetUserGrade(userID, req.Grade); err != nil {
+		if err.Error() == "user not found" {
+			utils.SendErrorResponse(c, http.StatusNotFound, "User not found")
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "User grade updated successfully", nil)
+}
+
+// SetUserJoinedAtRequest adalah request body untuk mengubah tanggal mulai bekerja user
+type SetUserJoinedAtRequest struct {
+	JoinedAt string `json:"joined_at" binding:"required" example:"2020-07-01"` // Format YYYY-MM-DD
+}
+
+// SetUserJoinedAt godoc
+// @Summary     Set a user's joined date
+// @Description Set the date the user started working, which determines their years of service for tier resolution and their pro-rated grant. If the new date entitles the user to more points for the current period than already granted, the difference is granted (admin only)
+// @Tags        admin-tiers
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "User ID"
+// @Param       request body SetUserJoinedAtRequest true "Joined date"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/users/{id}/joined-at [put]
+func (h *AdminHandler) SetUserJoinedAt(c *gin.Context) {
+	userID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var req SetUserJoinedAtRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	joinedAt, err := time.ParseInLocation("2006-01-02", req.JoinedAt, time.Local)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid joined_at format, use YYYY-MM-DD")
+		return
+	}
+
+	if err := h.grantService.SetUserJoinedAt(userID, joinedAt); err != nil {
+		if err.Error() == "user not found" {
+			utils.SendErrorResponse(c, http.StatusNotFound, "User not found")
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "User joined date updated successfully", nil)
+}
+
+// SETTINGS
+
+// GetSettings godoc
+// @Summary     Get application settings
+// @Description Get admin-configurable settings such as point transfer limits (admin only)
+// @Tags        admin-settings
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/settings [get]
+func (h *AdminHandler) GetSettings(c *gin.Context) {
+	settings, err := h.settingsService.GetSettings()
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Settings retrieved successfully", settings)
+}
+
+// UpdateTransferSettingsRequest adalah request body untuk mengubah batasan transfer point
+type UpdateTransferSettingsRequest struct {
+	Enabled     *bool `json:"enabled" binding:"required" example:"true"`
+	MinAmount   int   `json:"min_amount" binding:"required,min=1" example:"1"`
+	MaxAmount   int   `json:"max_amount" binding:"min=0" example:"10"`   // 0 = tanpa batas
+	AnnualLimit int   `json:"annual_limit" binding:"min=0" example:"24"` // 0 = tanpa batas
+}
+
+// UpdateTransferSettings godoc
+// @Summary     Update point transfer limits
+// @Description Enable or disable peer-to-peer point transfers and set their limits (admin only)
+// @Tags        admin-settings
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body UpdateTransferSettingsRequest true "Transfer limits"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/settings/transfer [put]
+func (h *AdminHandler) UpdateTransferSettings(c *gin.Context) {
+	var req UpdateTransferSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	settings, err := h.settingsService.UpdateTransferSettings(models.TransferSettings{
+		Enabled:     *req.Enabled,
+		MinAmount:   req.MinAmount,
+		MaxAmount:   req.MaxAmount,
+		AnnualLimit: req.AnnualLimit,
+	}, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "min_amount must be at least 1", "limits cannot be negative", "max_amount cannot be less than min_amount":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Transfer settings updated successfully", settings)
+}
+
+// UpdateCarryOverSettingsRequest adalah request body untuk mengubah aturan carry-over akhir tahun
+type UpdateCarryOverSettingsRequest struct {
+	Mode       string `json:"mode" binding:"required,oneof=none cap percentage" example:"cap"`
+	Cap        int    `json:"cap" binding:"min=0" example:"10"`                // Untuk mode cap
+	Percentage int    `json:"percentage" binding:"min=0,max=100" example:"50"` // Untuk mode percentage
+}
+
+// UpdateCarryOverSettings godoc
+// @Summary     Update year-end carry-over rule
+// @Description Set how many unused points carry over into the next point year: none (all carry over), cap, or percentage (admin only)
+// @Tags        admin-settings
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body UpdateCarryOverSettingsRequest true "Carry-over rule"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/settings/carry-over [put]
+func (h *AdminHandler) UpdateCarryOverSettings(c *gin.Context) {
+	var req UpdateCarryOverSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	settings, err := h.settingsService.UpdateCarryOverSettings(models.CarryOverSettings{
+		Mode:       req.Mode,
+		Cap:        req.Cap,
+		Percentage: req.Percentage,
+	}, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "invalid carry-over mode", "cap cannot be negative", "percentage must be between 0 and 100":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Carry-over settings updated successfully", settings)
+}
+
+// UpdatePricingSettingsRequest adalah request body untuk mengubah biaya point default
+type UpdatePricingSettingsRequest struct {
+	WeekendDays  []int `json:"weekend_days" binding:"dive,min=0,max=6" example:"0,6"` // 0 = Minggu ... 6 = Sabtu
+	WeekdayCost  int   `json:"weekday_cost" binding:"required,min=1" example:"1"`
+	WeekendCost  int   `json:"weekend_cost" binding:"required,min=1" example:"2"`
+	MaxPointCost int   `json:"max_point_cost" binding:"required,min=1" example:"3"` // Biaya tertinggi tanggal khusus dan aturan berulang
+}
+
+// UpdatePricingSettings godoc
+// @Summary     Update default point costs
+// @Description Set which days are weekend days, the point cost of weekdays and weekend days without a date rule, and the maximum cost of a date rule. Hotels may override the weekend days (admin only)
+// @Tags        admin-settings
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body UpdatePricingSettingsRequest true "Default point costs"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/settings/pricing [put]
+func (h *AdminHandler) UpdatePricingSettings(c *gin.Context) {
+	var req UpdatePricingSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	settings, err := h.settingsService.UpdatePricingSettings(models.PricingSettings{
+		WeekendDays:  toWeekdays(req.WeekendDays),
+		WeekdayCost:  req.WeekdayCost,
+		WeekendCost:  req.WeekendCost,
+		MaxPointCost: req.MaxPointCost,
+	}, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "weekday_cost and weekend_cost must be at least 1",
+			"max_point_cost cannot be less than weekday_cost or weekend_cost",
+			"weekend days must be between 0 (Sunday) and 6 (Saturday)":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Pricing settings updated successfully", settings)
+}
+
+// StayDiscountRequest adalah satu aturan potongan menginap lama
+type StayDiscountRequest struct {
+	MinNights  int `json:"min_nights" binding:"required,min=2" example:"7"`
+	FreeNights int `json:"free_nights" binding:"min=0" example:"1"`         // Malam termurah yang digratiskan
+	PercentOff int `json:"percent_off" binding:"min=0,max=100" example:"0"` // Persen potongan, dibulatkan ke bawah
+}
+
+// UpdateStayDiscountsRequest adalah request body untuk mengganti semua aturan potongan menginap lama
+type UpdateStayDiscountsRequest struct {
+	Discounts []StayDiscountRequest `json:"discounts" binding:"dive"`
+}
+
+// UpdateStayDiscounts godoc
+// @Summary     Update length-of-stay discounts
+// @Description Replace the length-of-stay discount rules. Each rule gives either free_nights (the cheapest nights are free) or percent_off (rounded down) to stays of at least min_nights; the rule with the highest min_nights reached applies. An empty list disables the discounts (admin only)
+// @Tags        admin-settings
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body UpdateStayDiscountsRequest true "Length-of-stay discounts"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/settings/stay-discounts [put]
+func (h *AdminHandler) UpdateStayDiscounts(c *gin.Context) {
+	var req UpdateStayDiscountsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	adminID := c.MustGet("userID").(primitive.ObjectID)
+
+	discounts := make([]models.StayDiscount, 0, len(req.Discounts))
+	for _, discount := range req.Discounts {
+		discounts = append(discounts, models.StayDiscount{
+			MinNights:  discount.MinNights,
+			FreeNights: discount.FreeNights,
+			PercentOff: discount.PercentOff,
+		})
+	}
+
+	settings, err := h.settingsService.UpdateStayDiscounts(discounts, adminID)
+	if err != nil {
+		switch err.Error() {
+		case "min_nights must be at least 2",
+			"only one discount per min_nights is allowed",
+			"set either free_nights or percent_off",
+			"free_nights must be less than min_nights",
+			"percent_off must be between 1 and 100":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Stay discounts updated successfully", settings)
+}
@@ -36,8 +36,13 @@ type CalculatePointCostRequest struct {
 
 // CalculatePointCostResponse adalah response untuk hasil perhitungan biaya point
 type CalculatePointCostResponse struct {
-	PointCost    int              `json:"point_cost"`
-	DailyDetails []DailyPointCost `json:"daily_details,omitempty"`
+	Subtotal     int                      `json:"subtotal"`            // Jumlah biaya semua malam sebelum potongan
+	Discounts    []models.BookingDiscount `json:"discounts,omitempty"` // Potongan yang mengurangi subtotal
+	PointCost    int                      `json:"point_cost"`          // Subtotal dikurangi potongan
+	DailyDetails []DailyPointCost         `json:"daily_details,omitempty"`
+	// QuoteToken dapat dikirim ke POST /bookings agar dikenai point_cost ini sampai QuoteExpiresAt
+	QuoteToken     string    `json:"quote_token"`
+	QuoteExpiresAt time.Time `json:"quote_expires_at"`
 }
 
 // DailyPointCost adalah detail biaya point per hari
@@ -46,11 +51,17 @@ type DailyPointCost struct {
 	DayType   string `json:"day_type"` // "regular", "weekend", "holiday"
 	PointCost int    `json:"point_cost"`
 	Name      string `json:"name,omitempty"` // Nama hari libur jika ada
+
+	// Komposisi biaya: point_cost = base_point_cost x hotel_multiplier x room_multiplier (dibulatkan)
+	BasePointCost   int     `json:"base_point_cost"`
+	HotelMultiplier float64 `json:"hotel_multiplier"`
+	RoomMultiplier  float64 `json:"room_multiplier"`
+	DiscountPoints  int     `json:"discount_points,omitempty"` // Potongan last-minute malam ini
 }
 
 // CalculatePointCost godoc
 // @Summary     Calculate booking point cost
-// @Description Calculate the point cost for a booking
+// @Description Calculate the point cost for a booking. check_in and check_out are dates in the hotel's time zone. Each night's date cost is multiplied by the hotel and room point multipliers, then last-minute and length-of-stay discounts are subtracted from the subtotal. The returned quote_token locks this cost for a short time when passed to POST /bookings
 // @Tags        bookings
 // @Accept      json
 // @Produce     json
@@ -89,8 +100,10 @@ func (h *BookingHandler) CalculatePointCost(c *gin.Context) {
 		return
 	}
 
-	// Hitung biaya point
-	pointCost, dailyPoints, err := h.bookingService.CalculatePointCostWithDetails(roomID, checkIn, checkOut)
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	// Hitung biaya point dan tanda tangani quote
+	quote, err := h.bookingService.IssueQuote(userID, roomID, checkIn, checkOut)
 	if err != nil {
 		if err.Error() == "room not found" {
 			utils.SendErrorResponse(c, http.StatusNotFound, "Room not found")
@@ -102,18 +115,28 @@ func (h *BookingHandler) CalculatePointCost(c *gin.Context) {
 
 	// Format daily details for response
 	var dailyDetails []DailyPointCost
-	for _, dp := range dailyPoints {
+	for _, dp := range quote.Nights {
 		dailyDetails = append(dailyDetails, DailyPointCost{
 			Date:      dp.Date.Format("2006-01-02"),
 			DayType:   dp.DayType,
 			PointCost: dp.PointCost,
 			Name:      dp.Name,
+
+			BasePointCost:   dp.BasePointCost,
+			HotelMultiplier: dp.HotelMultiplier,
+			RoomMultiplier:  dp.RoomMultiplier,
+			DiscountPoints:  dp.DiscountPoints,
 		})
 	}
 
 	response := CalculatePointCostResponse{
-		PointCost:    pointCost,
+		Subtotal:     quote.Subtotal,
+		Discounts:    quote.Discounts,
+		PointCost:    quote.TotalPoints,
 		DailyDetails: dailyDetails,
+
+		QuoteToken:     quote.Token,
+		QuoteExpiresAt: quote.ExpiresAt,
 	}
 
 	utils.SendSuccessResponse(c, http.StatusOK, "Point cost calculated successfully", response)
@@ -125,11 +148,23 @@ type CreateBookingRequest struct {
 	RoomID   string `json:"room_id" binding:"required" example:"60f1a5c29f48e1a8e8a8b123"`
 	CheckIn  string `json:"check_in" binding:"required" example:"2025-06-01"`  // Format YYYY-MM-DD
 	CheckOut string `json:"check_out" binding:"required" example:"2025-06-05"` // Format YYYY-MM-DD
+	// UsePool membayar booking bersama anggota pool pemesan
+	UsePool bool `json:"use_pool" example:"false"`
+	// PoolShares adalah bagian point tiap anggota pool, jika kosong point dibagi otomatis
+	PoolShares []PoolShareRequest `json:"pool_shares"`
+	// QuoteToken dari POST /bookings/calculate, jika diisi booking dikenai biaya quote tersebut
+	QuoteToken string `json:"quote_token"`
+}
+
+// PoolShareRequest adalah bagian point yang dibayar satu anggota pool
+type PoolShareRequest struct {
+	UserID    string `json:"user_id" binding:"required" example:"60f1a5c29f48e1a8e8a8b124"`
+	PointCost int    `json:"point_cost" binding:"required,min=1" example:"2"`
 }
 
 // CreateBooking godoc
 // @Summary     Create a new booking
-// @Description Create a new room booking using points
+// @Description Create a new room booking using points. With use_pool the cost is split across the members of the user's pool, either by the given pool_shares or automatically (the user's own points first). Other members can only be charged up to the share limit they set. check_in and check_out are dates in the hotel's time zone; the booking is saved with the hotel's check-in and check-out times. With a valid quote_token from /bookings/calculate the booking is charged exactly the quoted cost
 // @Tags        bookings
 // @Accept      json
 // @Produce     json
@@ -183,8 +218,21 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 		return
 	}
 
+	var pool *services.PoolPayment
+	if req.UsePool || len(req.PoolShares) > 0 {
+		pool = &services.PoolPayment{}
+		for _, share := range req.PoolShares {
+			memberID, err := primitive.ObjectIDFromHex(share.UserID)
+			if err != nil {
+				utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid pool share user ID format")
+				return
+			}
+			pool.Shares = append(pool.Shares, models.BookingShare{UserID: memberID, PointCost: share.PointCost})
+		}
+	}
+
 	// Create booking
-	booking, err := h.bookingService.CreateBooking(userObjID, hotelID, roomID, checkIn, checkOut)
+	booking, err := h.bookingService.CreateBooking(userObjID, hotelID, roomID, checkIn, checkOut, pool, req.QuoteToken)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 
@@ -202,6 +250,18 @@ func (h *BookingHandler) CreateBooking(c *gin.Context) {
 			statusCode = http.StatusBadRequest
 		case "check-in date cannot be in the past":
 			statusCode = http.StatusBadRequest
+		case "check-in date is beyond your booking window":
+			statusCode = http.StatusBadRequest
+		case "maximum active bookings reached":
+			statusCode = http.StatusBadRequest
+		case "user does not belong to a pool",
+			"pool share user is not a pool member",
+			"invalid pool shares",
+			"pool shares must add up to the point cost",
+			"pool share exceeds the member's share limit":
+			statusCode = http.StatusBadRequest
+		case "quote is invalid or has expired", "quote does not match the booking":
+			statusCode = http.StatusBadRequest
 		}
 
 		utils.SendErrorResponse(c, statusCode, err.Error())
@@ -241,7 +301,7 @@ func (h *BookingHandler) GetBookings(c *gin.Context) {
 
 // GetBookingById godoc
 // @Summary     Get booking details
-// @Description Get a specific booking by ID
+// @Description Get a specific booking by ID, including the per-night point breakdown saved when it was booked
 // @Tags        bookings
 // @Produce     json
 // @Security    BearerAuth
@@ -303,7 +363,7 @@ type CancelBookingRequest struct {
 
 // CancelBooking godoc
 // @Summary     Cancel booking
-// @Description Cancel a booking and refund points
+// @Description Cancel a booking and refund points. Bookings cannot be cancelled within 24 hours of the hotel's local check-in time
 // @Tags        bookings
 // @Accept      json
 // @Produce     json
@@ -390,3 +450,61 @@ func (h *BookingHandler) GetActiveBookings(c *gin.Context) {
 
 	utils.SendSuccessResponse(c, http.StatusOK, "Active bookings retrieved successfully", bookings)
 }
+
+// UpdateBookingStatusRequest adalah request body untuk mengubah status pemesanan
+type UpdateBookingStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending confirmed completed cancelled" example:"confirmed"`
+}
+
+// UpdateBookingStatus godoc
+// @Summary     Update booking status
+// @Description Approve (confirmed) or reject (cancelled) a pending booking, or change the status of any booking. Approving a pending booking deducts its held points, rejecting releases them. A cancelled booking can only be reactivated while its room is still free (admin only)
+// @Tags        admin-bookings
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       id path string true "Booking ID"
+// @Param       request body UpdateBookingStatusRequest true "New status"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     403 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /admin/bookings/{id}/status [put]
+func (h *BookingHandler) UpdateBookingStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := primitive.ObjectIDFromHex(idStr)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, "Invalid booking ID format")
+		return
+	}
+
+	var req UpdateBookingStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = h.bookingService.UpdateBookingStatus(id, req.Status)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+
+		// Handle specific errors
+		switch err.Error() {
+		case "booking not found":
+			statusCode = http.StatusNotFound
+		case "invalid booking status",
+			"insufficient point balance to reactivate booking",
+			"insufficient point balance to confirm booking",
+			"confirmed booking cannot be moved back to pending",
+			"room is not available for the selected dates":
+			statusCode = http.StatusBadRequest
+		}
+
+		utils.SendErrorResponse(c, statusCode, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Booking status updated successfully", nil)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/utils"
+)
+
+// PoolHandler menangani operasi pool point bersama
+type PoolHandler struct {
+	poolService services.PoolService
+}
+
+// NewPoolHandler membuat handler baru untuk pool point
+func NewPoolHandler(poolService services.PoolService) *PoolHandler {
+	return &PoolHandler{
+		poolService: poolService,
+	}
+}
+
+// CreatePoolRequest adalah request body untuk membuat pool
+type CreatePoolRequest struct {
+	Name string `json:"name" binding:"required,max=100" example:"Keluarga Santoso"`
+}
+
+// JoinPoolRequest adalah request body untuk bergabung dengan pool
+type JoinPoolRequest struct {
+	JoinCode string `json:"join_code" binding:"required" example:"3FA9C2D1"`
+}
+
+// SetShareLimitRequest adalah request body untuk mengatur batas point yang boleh dipakai anggota lain
+type SetShareLimitRequest struct {
+	MaxPoints int `json:"max_points" binding:"min=0" example:"10"` // 0 = anggota lain tidak bisa memakai point saya
+}
+
+// GetPool godoc
+// @Summary     Get my point pool
+// @Description Get the pool the authenticated user belongs to, with each member's available points
+// @Tags        pools
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /pools/mine [get]
+func (h *PoolHandler) GetPool(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	pool, err := h.poolService.GetUserPool(userID)
+	if err != nil {
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if pool == nil {
+		utils.SendErrorResponse(c, http.StatusNotFound, "You do not belong to a pool")
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Pool retrieved successfully", pool)
+}
+
+// CreatePool godoc
+// @Summary     Create a point pool
+// @Description Create a pool that other users can join with its join code to combine points for one booking
+// @Tags        pools
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body CreatePoolRequest true "Pool Information"
+// @Success     201 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /pools [post]
+func (h *PoolHandler) CreatePool(c *gin.Context) {
+	var req CreatePoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	pool, err := h.poolService.CreatePool(userID, req.Name)
+	if err != nil {
+		switch err.Error() {
+		case "pool name cannot be empty", "user already belongs to a pool":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusCreated, "Pool created successfully", pool)
+}
+
+// JoinPool godoc
+// @Summary     Join a point pool
+// @Description Join a pool using the join code shared by one of its members
+// @Tags        pools
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body JoinPoolRequest true "Join Code"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     404 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /pools/join [post]
+func (h *PoolHandler) JoinPool(c *gin.Context) {
+	var req JoinPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	pool, err := h.poolService.JoinPool(userID, req.JoinCode)
+	if err != nil {
+		switch err.Error() {
+		case "pool not found":
+			utils.SendErrorResponse(c, http.StatusNotFound, "Pool not found")
+		case "user already belongs to a pool":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Joined pool successfully", pool)
+}
+
+// LeavePool godoc
+// @Summary     Leave my point pool
+// @Description Leave the pool the authenticated user belongs to. Bookings already paid by the pool keep their split
+// @Tags        pools
+// @Produce     json
+// @Security    BearerAuth
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /pools/leave [post]
+func (h *PoolHandler) LeavePool(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	if err := h.poolService.LeavePool(userID); err != nil {
+		if err.Error() == "user does not belong to a pool" {
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Left pool successfully", nil)
+}
+
+// SetShareLimit godoc
+// @Summary     Set my pool share limit
+// @Description Consent to other pool members using up to max_points of the authenticated user's points in one booking. 0 (the default) means other members cannot charge the user
+// @Tags        pools
+// @Accept      json
+// @Produce     json
+// @Security    BearerAuth
+// @Param       request body SetShareLimitRequest true "Share limit"
+// @Success     200 {object} utils.APISuccessResponse
+// @Failure     400 {object} utils.APIErrorResponse
+// @Failure     401 {object} utils.APIErrorResponse
+// @Failure     500 {object} utils.APIErrorResponse
+// @Router      /pools/share-limit [put]
+func (h *PoolHandler) SetShareLimit(c *gin.Context) {
+	var req SetShareLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	pool, err := h.poolService.SetShareLimit(userID, req.MaxPoints)
+	if err != nil {
+		switch err.Error() {
+		case "user does not belong to a pool", "share limit cannot be negative":
+			utils.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		default:
+			utils.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	utils.SendSuccessResponse(c, http.StatusOK, "Share limit updated successfully", pool)
+}
@@ -2,23 +2,30 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
 	"hotel-point-app/internal/services"
+	"hotel-point-app/pkg/utils"
 )
 
 type UserHandler struct {
-	authService  services.AuthService
-	pointService services.PointService
+	authService      services.AuthService
+	pointService     services.PointService
+	statementService services.StatementService
 }
 
-func NewUserHandler(authService services.AuthService, pointService services.PointService) *UserHandler {
+func NewUserHandler(authService services.AuthService, pointService services.PointService, statementService services.StatementService) *UserHandler {
 	return &UserHandler{
-		authService:  authService,
-		pointService: pointService,
+		authService:      authService,
+		pointService:     pointService,
+		statementService: statementService,
 	}
 }
 
@@ -54,23 +61,141 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 func (h *UserHandler) GetPointBalance(c *gin.Context) {
 	userID := c.MustGet("userID").(primitive.ObjectID)
 
-	balance, err := h.pointService.GetPointBalance(userID)
+	balance, err := h.pointService.GetPointBreakdown(userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"point_balance": balance})
+	c.JSON(http.StatusOK, balance)
 }
 
 func (h *UserHandler) GetPointHistory(c *gin.Context) {
 	userID := c.MustGet("userID").(primitive.ObjectID)
 
-	transactions, err := h.pointService.GetPointHistory(userID)
+	var filter repositories.PointHistoryFilter
+	if fromDate := c.Query("from_date"); fromDate != "" {
+		date, err := time.Parse("2006-01-02", fromDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from_date format, use YYYY-MM-DD"})
+			return
+		}
+		filter.StartDate = date
+	}
+	if toDate := c.Query("to_date"); toDate != "" {
+		date, err := time.Parse("2006-01-02", toDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to_date format, use YYYY-MM-DD"})
+			return
+		}
+		filter.EndDate = date
+	}
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.StartDate.After(filter.EndDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from_date cannot be after to_date"})
+		return
+	}
+
+	// type dapat diisi beberapa kali atau dipisah koma, mis. type=transfer_in,transfer_out
+	for _, value := range c.QueryArray("type") {
+		for _, transactionType := range strings.Split(value, ",") {
+			if transactionType = strings.TrimSpace(transactionType); transactionType != "" {
+				filter.Types = append(filter.Types, transactionType)
+			}
+		}
+	}
+
+	params := utils.GetPaginationParams(c)
+
+	entries, total, err := h.pointService.FindPointHistory(userID, filter, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.CreatePaginationResult(total, params, entries))
+}
+
+func (h *UserHandler) GetPointStatement(c *gin.Context) {
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	year := time.Now().Year()
+	if yearStr := c.Query("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 || parsed > year {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+			return
+		}
+		year = parsed
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format, must be: csv or pdf"})
+		return
+	}
+
+	statement, err := h.statementService.GenerateStatement(userID, year)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"transactions": transactions})
+	filename := "point-statement-" + strconv.Itoa(year) + "." + format
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+
+	if format == "pdf" {
+		c.Header("Content-Type", "application/pdf")
+		c.Status(http.StatusOK)
+		if err := statement.WritePDF(c.Writer); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+	if err := statement.WriteCSV(c.Writer); err != nil {
+		c.Error(err)
+	}
+}
+
+type TransferPointsRequest struct {
+	RecipientEmail string `json:"recipient_email" binding:"required,email"`
+	Amount         int    `json:"amount" binding:"required,gt=0"`
+	Note           string `json:"note" binding:"max=200"`
+}
+
+func (h *UserHandler) TransferPoints(c *gin.Context) {
+	var req TransferPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.MustGet("userID").(primitive.ObjectID)
+
+	transfer, err := h.pointService.TransferPoints(userID, services.TransferRequest{
+		RecipientEmail: req.RecipientEmail,
+		Amount:         req.Amount,
+		Note:           req.Note,
+	})
+	if err != nil {
+		switch err.Error() {
+		case "recipient not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "point transfers are disabled":
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case "cannot transfer points to yourself",
+			"insufficient point balance",
+			"transfer amount is below the minimum",
+			"transfer amount exceeds the maximum",
+			"annual transfer limit exceeded":
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, transfer)
 }
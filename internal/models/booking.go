@@ -7,13 +7,56 @@ import (
 )
 
 type Booking struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	HotelID   primitive.ObjectID  `bson:"hotel_id" json:"hotel_id"`
+	RoomID    primitive.ObjectID  `bson:"room_id" json:"room_id"`
+	CheckIn   time.Time           `bson:"check_in" json:"check_in"`
+	CheckOut  time.Time           `bson:"check_out" json:"check_out"`
+	PointCost int                 `bson:"point_cost" json:"point_cost"`                     // Setelah potongan
+	Discounts []BookingDiscount   `bson:"discounts,omitempty" json:"discounts,omitempty"`   // Potongan yang mengurangi biaya point
+	Nights    []BookingNight      `bson:"nights,omitempty" json:"nights,omitempty"`         // Rincian biaya per malam saat booking dibuat
+	PoolID    *primitive.ObjectID `bson:"pool_id,omitempty" json:"pool_id,omitempty"`       // Pool yang membayar booking
+	Shares    []BookingShare      `bson:"shares,omitempty" json:"shares,omitempty"`         // Pembagian point antar anggota pool
+	Status    string              `bson:"status" json:"status"`                             // "pending", "confirmed", "completed", "cancelled"
+	HoldUntil *time.Time          `bson:"hold_until,omitempty" json:"hold_until,omitempty"` // Batas persetujuan booking pending
+	CreatedAt time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// BookingNight adalah biaya satu malam booking sesuai perhitungan saat booking dibuat
+type BookingNight struct {
+	Date           time.Time           `bson:"date" json:"date"`
+	DayType        string              `bson:"day_type" json:"day_type"` // "regular", "weekend", "holiday"
+	Name           string              `bson:"name,omitempty" json:"name,omitempty"`
+	PointCost      int                 `bson:"point_cost" json:"point_cost"`
+	DiscountPoints int                 `bson:"discount_points,omitempty" json:"discount_points,omitempty"` // Potongan last-minute
+	RuleID         *primitive.ObjectID `bson:"rule_id,omitempty" json:"rule_id,omitempty"`                 // DateRule atau RecurringDateRule yang menentukan biaya
+}
+
+// Jenis potongan booking
+const (
+	DiscountLengthOfStay = "length_of_stay"
+	DiscountLastMinute   = "last_minute"
+)
+
+// BookingDiscount adalah potongan point yang diterapkan pada booking
+type BookingDiscount struct {
+	Type        string `bson:"type" json:"type"`
+	Description string `bson:"description" json:"description"`
+	Points      int    `bson:"points" json:"points"`
+}
+
+// BookingShare adalah bagian point booking yang dibayar satu user
+type BookingShare struct {
 	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
-	HotelID   primitive.ObjectID `bson:"hotel_id" json:"hotel_id"`
-	RoomID    primitive.ObjectID `bson:"room_id" json:"room_id"`
-	CheckIn   time.Time          `bson:"check_in" json:"check_in"`
-	CheckOut  time.Time          `bson:"check_out" json:"check_out"`
 	PointCost int                `bson:"point_cost" json:"point_cost"`
-	Status    string             `bson:"status" json:"status"` // "pending", "confirmed", "cancelled"
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// PointShares mengembalikan pembayar booking beserta bagiannya.
+// Booking tanpa pool dibayar seluruhnya oleh pemesan.
+func (b *Booking) PointShares() []BookingShare {
+	if len(b.Shares) > 0 {
+		return b.Shares
+	}
+	return []BookingShare{{UserID: b.UserID, PointCost: b.PointCost}}
 }
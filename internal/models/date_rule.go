@@ -12,4 +12,29 @@ type DateRule struct {
 	Type      string             `bson:"type" json:"type"`             // "regular", "weekend", "holiday"
 	PointCost int                `bson:"point_cost" json:"point_cost"` // 1, 2, or 3
 	Name      string             `bson:"name" json:"name,omitempty"`   // For holidays
+	Version   int                `bson:"version" json:"version"`       // Nomor versi terakhir, lihat DateRuleVersion
+	UpdatedBy primitive.ObjectID `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// Perubahan yang dicatat pada versi DateRule
+const (
+	DateRuleCreated = "created"
+	DateRuleUpdated = "updated"
+	DateRuleDeleted = "deleted"
+)
+
+// DateRuleVersion adalah isi DateRule setelah satu perubahan. Versi berlaku sejak ChangedAt
+// sampai versi berikutnya; versi dengan Action deleted berarti tanggal tidak lagi memiliki aturan.
+type DateRuleVersion struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	RuleID    primitive.ObjectID `bson:"rule_id" json:"rule_id"`
+	Version   int                `bson:"version" json:"version"`
+	Action    string             `bson:"action" json:"action"` // "created", "updated", "deleted"
+	Date      time.Time          `bson:"date" json:"date"`
+	Type      string             `bson:"type" json:"type"`
+	PointCost int                `bson:"point_cost" json:"point_cost"`
+	Name      string             `bson:"name" json:"name,omitempty"`
+	ChangedBy primitive.ObjectID `bson:"changed_by,omitempty" json:"changed_by,omitempty"` // Kosong untuk perubahan dari CLI
+	ChangedAt time.Time          `bson:"changed_at" json:"changed_at"`
 }
@@ -1,11 +1,23 @@
 package models
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"time"
+	// Data zona waktu ikut di-embed agar LoadLocation tetap jalan di image tanpa tzdata
+	_ "time/tzdata"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Default zona waktu dan jam check-in/check-out untuk hotel yang belum mengaturnya
+const (
+	DefaultHotelTimeZone = "Asia/Jakarta" // WIB
+	DefaultCheckInTime   = "14:00"
+	DefaultCheckOutTime  = "12:00"
+)
+
 type Hotel struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Name        string             `bson:"name" json:"name"`
@@ -13,6 +25,124 @@ type Hotel struct {
 	Address     string             `bson:"address" json:"address"`
 	City        string             `bson:"city" json:"city"`
 	Image       string             `bson:"image" json:"image"`
-	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time          `bson:"updated_at" json:"updated_at"`
+	// PointMultiplier dikalikan dengan biaya point tanggal untuk semua kamar di hotel ini
+	PointMultiplier float64 `bson:"point_multiplier" json:"point_multiplier"`
+	// WeekendDays menggantikan hari weekend dari pengaturan untuk hotel ini, kosong berarti ikut pengaturan
+	WeekendDays []time.Weekday `bson:"weekend_days,omitempty" json:"weekend_days,omitempty" swaggertype:"array,integer"`
+	// LastMinuteDiscount menurunkan biaya malam yang dekat dan masih sepi, nil jika tidak aktif
+	LastMinuteDiscount *LastMinuteDiscount `bson:"last_minute_discount,omitempty" json:"last_minute_discount,omitempty"`
+	// TimeZone adalah nama zona waktu IANA hotel (Asia/Jakarta, Asia/Makassar, Asia/Jayapura).
+	// Batas hari, "hari ini" dan jam check-in/check-out dihitung di zona ini.
+	TimeZone     string    `bson:"time_zone,omitempty" json:"time_zone,omitempty" example:"Asia/Makassar"`
+	CheckInTime  string    `bson:"check_in_time,omitempty" json:"check_in_time,omitempty" example:"14:00"`   // Format HH:MM waktu lokal
+	CheckOutTime string    `bson:"check_out_time,omitempty" json:"check_out_time,omitempty" example:"12:00"` // Format HH:MM waktu lokal
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// EffectivePointMultiplier mengembalikan pengali biaya point hotel, 1 jika belum diatur
+func (h *Hotel) EffectivePointMultiplier() float64 {
+	if h.PointMultiplier <= 0 {
+		return 1
+	}
+	return h.PointMultiplier
+}
+
+// Location mengembalikan zona waktu hotel, Asia/Jakarta jika belum diatur atau hotel nil
+func (h *Hotel) Location() *time.Location {
+	name := DefaultHotelTimeZone
+	if h != nil && h.TimeZone != "" {
+		name = h.TimeZone
+	}
+
+	loc, err := LoadTimeZone(name)
+	if err != nil {
+		// TimeZone divalidasi saat disimpan, ini hanya terjadi pada data lama yang rusak
+		loc, _ = LoadTimeZone(DefaultHotelTimeZone)
+	}
+	return loc
+}
+
+// timeZones menyimpan zona waktu yang sudah dibaca, per nama zona
+var timeZones sync.Map
+
+// LoadTimeZone membaca zona waktu IANA name sekali lalu menyimpannya, karena Location dipanggil
+// untuk setiap malam dan booking. "Local" dan nama kosong ditolak karena bergantung pada server.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if cached, ok := timeZones.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	if name == "" || name == "Local" {
+		return nil, errors.New("invalid time zone")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	timeZones.Store(name, loc)
+	return loc, nil
+}
+
+// LocalDate mengembalikan tanggal kalender t di zona waktu hotel.
+// Tanggal kalender disimpan sebagai tengah malam UTC, sama seperti DateRule dan RoomAvailability.
+func (h *Hotel) LocalDate(t time.Time) time.Time {
+	local := t.In(h.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// CalendarDate mengembalikan tanggal kalender dari komponen tahun, bulan dan hari t apa adanya,
+// untuk tanggal yang sudah berupa tanggal lokal hotel seperti input YYYY-MM-DD
+func CalendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Today mengembalikan tanggal kalender hari ini di zona waktu hotel
+func (h *Hotel) Today() time.Time {
+	return h.LocalDate(time.Now())
+}
+
+// CheckInAt mengembalikan waktu check-in hotel pada tanggal kalender date
+func (h *Hotel) CheckInAt(date time.Time) time.Time {
+	clock := DefaultCheckInTime
+	if h != nil && h.CheckInTime != "" {
+		clock = h.CheckInTime
+	}
+	return h.atClock(date, clock)
+}
+
+// CheckOutAt mengembalikan waktu check-out hotel pada tanggal kalender date
+func (h *Hotel) CheckOutAt(date time.Time) time.Time {
+	clock := DefaultCheckOutTime
+	if h != nil && h.CheckOutTime != "" {
+		clock = h.CheckOutTime
+	}
+	return h.atClock(date, clock)
+}
+
+// atClock menggabungkan tanggal kalender date dengan jam HH:MM waktu lokal hotel
+func (h *Hotel) atClock(date time.Time, clock string) time.Time {
+	hour, minute, err := ParseClock(clock)
+	if err != nil {
+		hour, minute = 0, 0
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), hour, minute, 0, 0, h.Location())
+}
+
+// ParseClock membaca jam dengan format HH:MM (00:00 sampai 23:59)
+func ParseClock(clock string) (int, int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q is not a HH:MM time", clock)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// LastMinuteDiscount memberi potongan PercentOff persen (dibulatkan ke bawah) untuk malam
+// dalam WithinDays hari dari hari ini yang okupansi hotelnya di bawah OccupancyBelow persen
+type LastMinuteDiscount struct {
+	WithinDays     int `bson:"within_days" json:"within_days"`
+	OccupancyBelow int `bson:"occupancy_below" json:"occupancy_below"`
+	PercentOff     int `bson:"percent_off" json:"percent_off"`
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PointLot adalah sekumpulan point dari satu grant yang kedaluwarsa bersamaan
+type PointLot struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Source    string             `bson:"source" json:"source"`       // Tipe transaksi asal, mis. "annual_grant"
+	Reference string             `bson:"reference" json:"reference"` // Reference transaksi asal
+	Amount    int                `bson:"amount" json:"amount"`       // Jumlah point awal
+	Remaining int                `bson:"remaining" json:"remaining"` // Sisa point yang belum dipakai
+	ExpiresAt time.Time          `bson:"expires_at" json:"expires_at"`
+	Expired   bool               `bson:"expired" json:"expired"`
+	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// LotAllocation mencatat jumlah point yang diambil dari (atau dikembalikan ke) satu lot
+type LotAllocation struct {
+	LotID  primitive.ObjectID `bson:"lot_id" json:"lot_id"`
+	Amount int                `bson:"amount" json:"amount"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pool adalah kelompok user (mis. satu rumah tangga) yang dapat menggabungkan point untuk satu booking
+type Pool struct {
+	ID        primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	Name      string               `bson:"name" json:"name"`
+	JoinCode  string               `bson:"join_code" json:"join_code"` // Dibagikan kepada user yang ingin bergabung
+	MemberIDs []primitive.ObjectID `bson:"member_ids" json:"member_ids"`
+	// ShareLimits adalah persetujuan tiap anggota (kunci: user ID hex): point terbanyak miliknya
+	// yang boleh dipakai anggota lain dalam satu booking. Anggota tanpa batas tidak bisa ditagih anggota lain.
+	ShareLimits map[string]int `bson:"share_limits,omitempty" json:"share_limits,omitempty"`
+	CreatedAt   time.Time      `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+// HasMember memeriksa apakah user adalah anggota pool
+func (p *Pool) HasMember(userID primitive.ObjectID) bool {
+	for _, id := range p.MemberIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ShareLimit mengembalikan point terbanyak milik userID yang boleh dipakai anggota lain dalam satu booking
+func (p *Pool) ShareLimit(userID primitive.ObjectID) int {
+	return p.ShareLimits[userID.Hex()]
+}
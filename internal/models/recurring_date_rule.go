@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Pola pengulangan RecurringDateRule
+const (
+	RecurrenceYearly     = "yearly"      // Setiap tahun pada Month/Day, mis. 17 Agustus
+	RecurrenceNthWeekday = "nth_weekday" // Weekday ke-Week dalam Month (0 = setiap bulan), mis. Senin kedua Mei
+	RecurrenceRange      = "range"       // Setiap tahun dari Month/Day sampai EndMonth/EndDay, dapat melewati akhir tahun
+)
+
+// RecurringDateRule adalah aturan biaya point yang berulang, dievaluasi saat menghitung harga
+// tanpa menyimpan satu DateRule per hari. DateRule untuk tanggal tertentu tetap lebih diutamakan.
+type RecurringDateRule struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name       string             `bson:"name" json:"name"`
+	Type       string             `bson:"type" json:"type"` // "regular", "weekend", "holiday"
+	PointCost  int                `bson:"point_cost" json:"point_cost"`
+	Recurrence string             `bson:"recurrence" json:"recurrence"`
+	Month      int                `bson:"month" json:"month"`                             // 1-12, 0 untuk nth_weekday setiap bulan
+	Day        int                `bson:"day,omitempty" json:"day,omitempty"`             // Untuk yearly dan range
+	Weekday    time.Weekday       `bson:"weekday" json:"weekday"`                         // 0 = Minggu, untuk nth_weekday
+	Week       int                `bson:"week,omitempty" json:"week,omitempty"`           // 1-5, -1 untuk minggu terakhir, untuk nth_weekday
+	EndMonth   int                `bson:"end_month,omitempty" json:"end_month,omitempty"` // Untuk range
+	EndDay     int                `bson:"end_day,omitempty" json:"end_day,omitempty"`     // Untuk range
+	ValidFrom  *time.Time         `bson:"valid_from,omitempty" json:"valid_from,omitempty"`
+	ValidUntil *time.Time         `bson:"valid_until,omitempty" json:"valid_until,omitempty"`
+	Priority   int                `bson:"priority" json:"priority"` // Aturan dengan prioritas tertinggi menang jika beberapa cocok
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// Matches memeriksa apakah aturan berlaku pada tanggal date
+func (r *RecurringDateRule) Matches(date time.Time) bool {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if r.ValidFrom != nil && day.Before(time.Date(r.ValidFrom.Year(), r.ValidFrom.Month(), r.ValidFrom.Day(), 0, 0, 0, 0, date.Location())) {
+		return false
+	}
+	if r.ValidUntil != nil && day.After(time.Date(r.ValidUntil.Year(), r.ValidUntil.Month(), r.ValidUntil.Day(), 0, 0, 0, 0, date.Location())) {
+		return false
+	}
+
+	switch r.Recurrence {
+	case RecurrenceYearly:
+		return int(day.Month()) == r.Month && day.Day() == r.Day
+	case RecurrenceNthWeekday:
+		if r.Month != 0 && int(day.Month()) != r.Month {
+			return false
+		}
+		if day.Weekday() != r.Weekday {
+			return false
+		}
+		if r.Week == -1 {
+			return day.AddDate(0, 0, 7).Month() != day.Month()
+		}
+		return (day.Day()-1)/7+1 == r.Week
+	case RecurrenceRange:
+		current := int(day.Month())*100 + day.Day()
+		start := r.Month*100 + r.Day
+		end := r.EndMonth*100 + r.EndDay
+		if start <= end {
+			return current >= start && current <= end
+		}
+		// Rentang yang melewati akhir tahun, mis. 20 Desember - 5 Januari
+		return current >= start || current <= end
+	}
+
+	return false
+}
@@ -11,4 +11,14 @@ type Room struct {
 	Description string             `bson:"description" json:"description"`
 	Capacity    int                `bson:"capacity" json:"capacity"`
 	Image       string             `bson:"image" json:"image"`
+	// PointMultiplier dikalikan dengan biaya point tanggal dan pengali hotel untuk kamar ini
+	PointMultiplier float64 `bson:"point_multiplier" json:"point_multiplier"`
+}
+
+// EffectivePointMultiplier mengembalikan pengali biaya point kamar, 1 jika belum diatur
+func (r *Room) EffectivePointMultiplier() float64 {
+	if r.PointMultiplier <= 0 {
+		return 1
+	}
+	return r.PointMultiplier
 }
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SettingsID adalah ID dokumen tunggal pada collection settings
+const SettingsID = "app"
+
+// Settings adalah pengaturan aplikasi yang dapat diubah admin
+type Settings struct {
+	ID        string            `bson:"_id" json:"-"`
+	Transfer  TransferSettings  `bson:"transfer" json:"transfer"`
+	CarryOver CarryOverSettings `bson:"carry_over" json:"carry_over"`
+	Pricing   PricingSettings   `bson:"pricing" json:"pricing"`
+	// StayDiscounts adalah potongan menginap lama, aturan dengan MinNights tertinggi yang terpenuhi berlaku
+	StayDiscounts []StayDiscount     `bson:"stay_discounts" json:"stay_discounts"`
+	UpdatedBy     primitive.ObjectID `bson:"updated_by,omitempty" json:"updated_by,omitempty"`
+	UpdatedAt     time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// TransferSettings adalah batasan transfer point antar user, 0 berarti tanpa batas
+type TransferSettings struct {
+	Enabled     bool `bson:"enabled" json:"enabled"`
+	MinAmount   int  `bson:"min_amount" json:"min_amount"`
+	MaxAmount   int  `bson:"max_amount" json:"max_amount"`     // Per transfer
+	AnnualLimit int  `bson:"annual_limit" json:"annual_limit"` // Total point yang dikirim per tahun kalender
+}
+
+// Mode carry-over point di akhir tahun
+const (
+	CarryOverNone       = "none"       // Semua sisa point tetap berlaku
+	CarryOverCap        = "cap"        // Paling banyak Cap point dibawa ke tahun berikutnya
+	CarryOverPercentage = "percentage" // Percentage persen sisa point dibawa ke tahun berikutnya
+)
+
+// CarryOverSettings adalah aturan sisa point yang boleh dibawa ke tahun berikutnya
+type CarryOverSettings struct {
+	Mode       string `bson:"mode" json:"mode"`
+	Cap        int    `bson:"cap" json:"cap"`
+	Percentage int    `bson:"percentage" json:"percentage"`
+}
+
+// PricingSettings adalah biaya point default untuk tanggal tanpa aturan khusus
+type PricingSettings struct {
+	WeekendDays  []time.Weekday `bson:"weekend_days" json:"weekend_days" swaggertype:"array,integer"` // 0 = Minggu ... 6 = Sabtu
+	WeekdayCost  int            `bson:"weekday_cost" json:"weekday_cost"`
+	WeekendCost  int            `bson:"weekend_cost" json:"weekend_cost"`
+	MaxPointCost int            `bson:"max_point_cost" json:"max_point_cost"` // Biaya tertinggi aturan tanggal
+}
+
+// IsWeekend memeriksa apakah hari tersebut termasuk weekend
+func (p PricingSettings) IsWeekend(day time.Weekday) bool {
+	for _, weekendDay := range p.WeekendDays {
+		if weekendDay == day {
+			return true
+		}
+	}
+	return false
+}
+
+// StayDiscount adalah potongan point untuk menginap minimal MinNights malam.
+// Hanya salah satu yang diisi: FreeNights (malam termurah gratis) atau PercentOff
+// (persen dari total, potongan dibulatkan ke bawah).
+type StayDiscount struct {
+	MinNights  int `bson:"min_nights" json:"min_nights"`
+	FreeNights int `bson:"free_nights" json:"free_nights"`
+	PercentOff int `bson:"percent_off" json:"percent_off"`
+}
+
+// YearEndClosure mencatat tahun point yang sudah ditutup oleh job akhir tahun
+type YearEndClosure struct {
+	Year            int               `bson:"_id" json:"year"`
+	Rule            CarryOverSettings `bson:"rule" json:"rule"`
+	UsersForfeited  int               `bson:"users_forfeited" json:"users_forfeited"`
+	PointsForfeited int               `bson:"points_forfeited" json:"points_forfeited"`
+	ClosedAt        time.Time         `bson:"closed_at" json:"closed_at"`
+}
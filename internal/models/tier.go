@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Tier adalah tingkat hak point berdasarkan grade dan masa kerja
+type Tier struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name              string             `bson:"name" json:"name"`
+	Grades            []string           `bson:"grades,omitempty" json:"grades,omitempty"` // Kosong berarti berlaku untuk semua grade
+	MinYearsOfService int                `bson:"min_years_of_service" json:"min_years_of_service"`
+	AnnualPoints      int                `bson:"annual_points" json:"annual_points"`
+	MaxActiveBookings int                `bson:"max_active_bookings" json:"max_active_bookings"` // 0 berarti tanpa batas
+	BookingWindowDays int                `bson:"booking_window_days" json:"booking_window_days"` // Paling jauh check-in dari hari ini, 0 berarti tanpa batas
+	CreatedAt         time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt         time.Time          `bson:"updated_at" json:"updated_at"`
+}
@@ -11,22 +11,65 @@ const (
 	RoleAdmin = "admin"
 )
 
+// Tipe transaksi point
+const (
+	TransactionAnnualGrant           = "annual_grant"
+	TransactionProratedGrant         = "prorated_grant" // Grant sebagian untuk user yang mulai bekerja di tengah periode
+	TransactionGrantTopUp            = "grant_top_up"   // Kekurangan grant periode setelah tanggal mulai bekerja dikoreksi
+	TransactionBookingDeduction      = "booking_deduction"
+	TransactionBookingRefund         = "booking_refund"
+	TransactionBookingReactivation   = "booking_reactivation"
+	TransactionBookingDeletionRefund = "booking_deletion_refund"
+	TransactionAdjustment            = "adjustment" // Koreksi ledger hasil rekonsiliasi
+	TransactionPointExpiry           = "point_expiry"
+	TransactionTransferOut           = "transfer_out"
+	TransactionTransferIn            = "transfer_in"
+	TransactionAdminAdjustment       = "admin_adjustment" // Reference berisi kode alasan
+	TransactionYearEndForfeit        = "year_end_forfeit" // Reference berisi tahun point, mis. "2026"
+)
+
 type User struct {
 	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Name         string             `bson:"name" json:"name"`
 	Email        string             `bson:"email" json:"email"`
 	Password     string             `bson:"password" json:"-"`
 	PointBalance int                `bson:"point_balance" json:"point_balance"`
-	Role         string             `bson:"role" json:"role"` // "user" atau "admin"
+	HeldPoints   int                `bson:"held_points" json:"held_points"`                 // Point yang ditahan booking pending
+	Role         string             `bson:"role" json:"role"`                               // "user" atau "admin"
+	Grade        string             `bson:"grade,omitempty" json:"grade,omitempty"`         // Grade karyawan, menentukan tier
+	JoinedAt     *time.Time         `bson:"joined_at,omitempty" json:"joined_at,omitempty"` // Tanggal mulai bekerja
 	CreatedAt    time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt    time.Time          `bson:"updated_at" json:"updated_at"`
 }
 
+// ServiceStart adalah awal masa kerja user, tanggal registrasi jika tanggal mulai bekerja tidak diisi
+func (u *User) ServiceStart() time.Time {
+	if u.JoinedAt != nil {
+		return *u.JoinedAt
+	}
+	return u.CreatedAt
+}
+
+// AvailablePoints adalah saldo yang dapat dipakai, yaitu saldo dikurangi point yang ditahan
+func (u *User) AvailablePoints() int {
+	return u.PointBalance - u.HeldPoints
+}
+
 type PointTransaction struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
-	Amount    int                `bson:"amount" json:"amount"`
-	Type      string             `bson:"type" json:"type"`           // "annual_grant", "booking_deduction"
-	Reference string             `bson:"reference" json:"reference"` // e.g., booking ID
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Amount         int                 `bson:"amount" json:"amount"`
+	Type           string              `bson:"type" json:"type"`           // "annual_grant", "booking_deduction"
+	Reference      string              `bson:"reference" json:"reference"` // e.g., booking ID, periode grant ("2026"), atau kode alasan adjustment
+	Lots           []LotAllocation     `bson:"lots,omitempty" json:"lots,omitempty"`
+	CounterpartyID *primitive.ObjectID `bson:"counterparty_id,omitempty" json:"counterparty_id,omitempty"` // User lawan transaksi transfer
+	ActorID        *primitive.ObjectID `bson:"actor_id,omitempty" json:"actor_id,omitempty"`               // Admin yang melakukan adjustment
+	Note           string              `bson:"note,omitempty" json:"note,omitempty"`
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// PointHistoryEntry adalah transaksi point beserta saldo setelah transaksi tersebut
+type PointHistoryEntry struct {
+	PointTransaction `bson:",inline"`
+	BalanceAfter     int `bson:"balance_after" json:"balance_after"`
 }
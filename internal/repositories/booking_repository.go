@@ -63,6 +63,23 @@ type BookingRepository interface {
 	// @Return error - nil jika berhasil, error jika gagal
 	UpdateStatus(id primitive.ObjectID, status string) error
 
+	// UpdateCheckTimes godoc
+	// @Summary Memperbarui waktu check-in dan check-out pemesanan
+	// @Description Mengubah waktu check-in dan check-out tanpa mengubah field lain
+	// @Param id primitive.ObjectID - ID pemesanan
+	// @Param checkIn time.Time - Waktu check-in baru
+	// @Param checkOut time.Time - Waktu check-out baru
+	// @Return error - nil jika berhasil, error jika gagal
+	UpdateCheckTimes(id primitive.ObjectID, checkIn, checkOut time.Time) error
+
+	// UpdateHoldUntil godoc
+	// @Summary Memperbarui batas persetujuan pemesanan
+	// @Description Mengubah batas waktu point ditahan untuk pemesanan pending
+	// @Param id primitive.ObjectID - ID pemesanan
+	// @Param holdUntil *time.Time - Batas persetujuan baru
+	// @Return error - nil jika berhasil, error jika gagal
+	UpdateHoldUntil(id primitive.ObjectID, holdUntil *time.Time) error
+
 	// Delete godoc
 	// @Summary Menghapus pemesanan
 	// @Description Menghapus pemesanan dari database
@@ -97,6 +114,16 @@ type BookingRepository interface {
 	// @Return error - nil jika berhasil, error jika gagal
 	FindActiveByRoomIDAndDateRange(roomID primitive.ObjectID, checkIn, checkOut time.Time) ([]models.Booking, error)
 
+	// FindActiveByHotelIDAndDateRange godoc
+	// @Summary Mencari pemesanan aktif untuk hotel dalam rentang waktu
+	// @Description Mendapatkan pemesanan hotel yang tidak dibatalkan dan beririsan dengan rentang [from, to)
+	// @Param hotelID primitive.ObjectID - ID hotel
+	// @Param from time.Time - Awal rentang
+	// @Param to time.Time - Akhir rentang (eksklusif)
+	// @Return []models.Booking - Daftar pemesanan
+	// @Return error - nil jika berhasil, error jika gagal
+	FindActiveByHotelIDAndDateRange(hotelID primitive.ObjectID, from, to time.Time) ([]models.Booking, error)
+
 	// CheckRoomAvailability godoc
 	// @Summary Memeriksa ketersediaan kamar
 	// @Description Memeriksa apakah kamar tersedia pada rentang tanggal tertentu
@@ -107,6 +134,14 @@ type BookingRepository interface {
 	// @Return error - nil jika berhasil, error jika gagal
 	CheckRoomAvailability(roomID primitive.ObjectID, checkIn, checkOut time.Time) (bool, error)
 
+	// LockRoom menulis dokumen kunci kamar di dalam transaksi sehingga transaksi booking lain
+	// untuk kamar yang sama bentrok (write conflict) dan diulang setelah transaksi ini selesai
+	LockRoom(roomID primitive.ObjectID) error
+
+	// LockUser menulis dokumen kunci booking user di dalam transaksi sehingga transaksi booking
+	// lain oleh user yang sama bentrok, mis. saat menghitung batas booking aktif
+	LockUser(userID primitive.ObjectID) error
+
 	// GetBookingsCount godoc
 	// @Summary Mendapatkan jumlah pemesanan
 	// @Description Mendapatkan jumlah pemesanan dalam rentang tanggal tertentu
@@ -137,14 +172,38 @@ type BookingRepository interface {
 	// @Return int64 - Total jumlah pemesanan
 	// @Return error - nil jika berhasil, error jika gagal
 	Search(query string, status string, page, limit int) ([]models.Booking, int64, error)
+
+	// FindExpiredHolds godoc
+	// @Summary Mencari pemesanan pending yang kedaluwarsa
+	// @Description Mendapatkan pemesanan pending yang batas persetujuannya (hold_until) sudah lewat
+	// @Param now time.Time - Waktu acuan
+	// @Return []models.Booking - Daftar pemesanan
+	// @Return error - nil jika berhasil, error jika gagal
+	FindExpiredHolds(now time.Time) ([]models.Booking, error)
+
+	// WithContext godoc
+	// @Summary Repository dengan context tertentu
+	// @Description Mengembalikan repository yang menjalankan query dengan ctx, mis. session dari TransactionManager
+	// @Param ctx context.Context - Context untuk query
+	// @Return BookingRepository - Repository yang terikat ke ctx
+	WithContext(ctx context.Context) BookingRepository
 }
 
 type bookingRepository struct {
-	db *mongo.Database
+	db  *mongo.Database
+	ctx context.Context
 }
 
 func NewBookingRepository(db *mongo.Database) BookingRepository {
-	return &bookingRepository{db: db}
+	return &bookingRepository{db: db, ctx: context.Background()}
+}
+
+func (r *bookingRepository) WithContext(ctx context.Context) BookingRepository {
+	return &bookingRepository{db: r.db, ctx: ctx}
+}
+
+func (r *bookingRepository) context() context.Context {
+	return r.ctx
 }
 
 func (r *bookingRepository) Create(booking *models.Booking) error {
@@ -165,7 +224,7 @@ func (r *bookingRepository) Create(booking *models.Booking) error {
 		booking.ID = primitive.NewObjectID()
 	}
 
-	_, err := collection.InsertOne(context.Background(), booking)
+	_, err := collection.InsertOne(r.context(), booking)
 	return err
 }
 
@@ -173,7 +232,7 @@ func (r *bookingRepository) FindByID(id primitive.ObjectID) (*models.Booking, er
 	var booking models.Booking
 
 	collection := r.db.Collection("bookings")
-	err := collection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&booking)
+	err := collection.FindOne(r.context(), bson.M{"_id": id}).Decode(&booking)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errors.New("booking not found")
@@ -189,7 +248,7 @@ func (r *bookingRepository) FindByUserID(userID primitive.ObjectID) ([]models.Bo
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{"user_id": userID},
 		options.Find().SetSort(bson.M{"created_at": -1}),
 	)
@@ -197,9 +256,9 @@ func (r *bookingRepository) FindByUserID(userID primitive.ObjectID) ([]models.Bo
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
@@ -211,7 +270,7 @@ func (r *bookingRepository) FindByHotelID(hotelID primitive.ObjectID) ([]models.
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{"hotel_id": hotelID},
 		options.Find().SetSort(bson.M{"check_in": 1}),
 	)
@@ -219,9 +278,9 @@ func (r *bookingRepository) FindByHotelID(hotelID primitive.ObjectID) ([]models.
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
@@ -233,7 +292,7 @@ func (r *bookingRepository) FindByRoomID(roomID primitive.ObjectID) ([]models.Bo
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{"room_id": roomID},
 		options.Find().SetSort(bson.M{"check_in": 1}),
 	)
@@ -241,15 +300,26 @@ func (r *bookingRepository) FindByRoomID(roomID primitive.ObjectID) ([]models.Bo
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
 	return bookings, nil
 }
 
+func (r *bookingRepository) UpdateCheckTimes(id primitive.ObjectID, checkIn, checkOut time.Time) error {
+	collection := r.db.Collection("bookings")
+
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"check_in": checkIn, "check_out": checkOut}},
+	)
+	return err
+}
+
 func (r *bookingRepository) UpdateStatus(id primitive.ObjectID, status string) error {
 	collection := r.db.Collection("bookings")
 
@@ -259,16 +329,26 @@ func (r *bookingRepository) UpdateStatus(id primitive.ObjectID, status string) e
 	}
 
 	_, err := collection.UpdateOne(
-		context.Background(),
+		r.context(),
 		bson.M{"_id": id},
 		bson.M{"$set": bson.M{"status": status}},
 	)
 	return err
 }
 
+func (r *bookingRepository) UpdateHoldUntil(id primitive.ObjectID, holdUntil *time.Time) error {
+	collection := r.db.Collection("bookings")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"hold_until": holdUntil}},
+	)
+	return err
+}
+
 func (r *bookingRepository) Delete(id primitive.ObjectID) error {
 	collection := r.db.Collection("bookings")
-	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	_, err := collection.DeleteOne(r.context(), bson.M{"_id": id})
 	return err
 }
 
@@ -277,7 +357,7 @@ func (r *bookingRepository) FindActiveByUserID(userID primitive.ObjectID) ([]mod
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"user_id":   userID,
 			"status":    bson.M{"$nin": []string{"cancelled", "completed"}},
@@ -289,9 +369,34 @@ func (r *bookingRepository) FindActiveByUserID(userID primitive.ObjectID) ([]mod
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) FindExpiredHolds(now time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+
+	collection := r.db.Collection("bookings")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{
+			"status":     "pending",
+			"hold_until": bson.M{"$lte": now},
+		},
+		options.Find().SetSort(bson.M{"hold_until": 1}),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
@@ -303,7 +408,7 @@ func (r *bookingRepository) FindByDateRange(startDate, endDate time.Time) ([]mod
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"$or": []bson.M{
 				{
@@ -330,9 +435,34 @@ func (r *bookingRepository) FindByDateRange(startDate, endDate time.Time) ([]mod
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
+		return nil, err
+	}
+
+	return bookings, nil
+}
+
+func (r *bookingRepository) FindActiveByHotelIDAndDateRange(hotelID primitive.ObjectID, from, to time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+
+	collection := r.db.Collection("bookings")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{
+			"hotel_id":  hotelID,
+			"status":    bson.M{"$ne": "cancelled"},
+			"check_in":  bson.M{"$lt": to},
+			"check_out": bson.M{"$gt": from},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
@@ -344,7 +474,7 @@ func (r *bookingRepository) FindActiveByRoomIDAndDateRange(roomID primitive.Obje
 
 	collection := r.db.Collection("bookings")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"room_id": roomID,
 			"status":  bson.M{"$ne": "cancelled"},
@@ -361,9 +491,9 @@ func (r *bookingRepository) FindActiveByRoomIDAndDateRange(roomID primitive.Obje
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, err
 	}
 
@@ -375,7 +505,7 @@ func (r *bookingRepository) CheckRoomAvailability(roomID primitive.ObjectID, che
 
 	// Find any overlapping bookings
 	count, err := collection.CountDocuments(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"room_id": roomID,
 			"status":  bson.M{"$ne": "cancelled"},
@@ -396,12 +526,34 @@ func (r *bookingRepository) CheckRoomAvailability(roomID primitive.ObjectID, che
 	return count == 0, nil
 }
 
+func (r *bookingRepository) LockRoom(roomID primitive.ObjectID) error {
+	collection := r.db.Collection("room_locks")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": roomID},
+		bson.M{"$inc": bson.M{"version": 1}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *bookingRepository) LockUser(userID primitive.ObjectID) error {
+	collection := r.db.Collection("user_booking_locks")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": userID},
+		bson.M{"$inc": bson.M{"version": 1}},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
 func (r *bookingRepository) GetBookingsCount(startDate, endDate time.Time) (int64, error) {
 	collection := r.db.Collection("bookings")
 
 	// Count bookings in the given date range
 	count, err := collection.CountDocuments(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"status": bson.M{"$ne": "cancelled"},
 			"$or": []bson.M{
@@ -434,7 +586,7 @@ func (r *bookingRepository) FindAll(page, limit int) ([]models.Booking, int64, e
 	collection := r.db.Collection("bookings")
 
 	// Get total count
-	totalCount, err := collection.CountDocuments(context.Background(), bson.M{})
+	totalCount, err := collection.CountDocuments(r.context(), bson.M{})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -448,13 +600,13 @@ func (r *bookingRepository) FindAll(page, limit int) ([]models.Booking, int64, e
 		SetSkip(skip).
 		SetLimit(int64(limit))
 
-	cursor, err := collection.Find(context.Background(), bson.M{}, opts)
+	cursor, err := collection.Find(r.context(), bson.M{}, opts)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, 0, err
 	}
 
@@ -482,7 +634,7 @@ func (r *bookingRepository) Search(query string, status string, page, limit int)
 	}
 
 	// Get total count
-	totalCount, err := collection.CountDocuments(context.Background(), filter)
+	totalCount, err := collection.CountDocuments(r.context(), filter)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -496,13 +648,13 @@ func (r *bookingRepository) Search(query string, status string, page, limit int)
 		SetSkip(skip).
 		SetLimit(int64(limit))
 
-	cursor, err := collection.Find(context.Background(), filter, opts)
+	cursor, err := collection.Find(r.context(), filter, opts)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &bookings); err != nil {
+	if err = cursor.All(r.context(), &bookings); err != nil {
 		return nil, 0, err
 	}
 
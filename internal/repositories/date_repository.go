@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -14,32 +15,57 @@ import (
 
 type DateRepository interface {
 	FindDateRules(startDate, endDate time.Time) ([]models.DateRule, error)
-	GetPointCostForDate(date time.Time) (int, error)
+	FindDateRuleByID(id primitive.ObjectID) (*models.DateRule, error)
+
+	// FindDateRuleVersions mengembalikan semua versi satu DateRule, versi terlama lebih dulu
+	FindDateRuleVersions(ruleID primitive.ObjectID) ([]models.DateRuleVersion, error)
+	// FindDateRuleVersionsInRange mengembalikan semua versi untuk tanggal dalam rentang, versi terlama lebih dulu
+	FindDateRuleVersionsInRange(startDate, endDate time.Time) ([]models.DateRuleVersion, error)
+	CreateDateRuleVersion(version *models.DateRuleVersion) error
+
+	// FindRecurringRules mengembalikan semua aturan berulang, prioritas tertinggi lebih dulu
+	FindRecurringRules() ([]models.RecurringDateRule, error)
+	FindRecurringRuleByID(id primitive.ObjectID) (*models.RecurringDateRule, error)
 
 	// Admin functions
 	CreateDateRule(rule *models.DateRule) error
 	UpdateDateRule(rule *models.DateRule) error
 	DeleteDateRule(id primitive.ObjectID) error
+	CreateRecurringRule(rule *models.RecurringDateRule) error
+	UpdateRecurringRule(rule *models.RecurringDateRule) error
+	DeleteRecurringRule(id primitive.ObjectID) error
+
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx, mis. session dari TransactionManager
+	WithContext(ctx context.Context) DateRepository
 }
 
 type dateRepository struct {
-	db *mongo.Database
+	db  *mongo.Database
+	ctx context.Context
 }
 
 func NewDateRepository(db *mongo.Database) DateRepository {
-	return &dateRepository{db: db}
+	return &dateRepository{db: db, ctx: context.Background()}
+}
+
+func (r *dateRepository) WithContext(ctx context.Context) DateRepository {
+	return &dateRepository{db: r.db, ctx: ctx}
+}
+
+func (r *dateRepository) context() context.Context {
+	return r.ctx
 }
 
 func (r *dateRepository) FindDateRules(startDate, endDate time.Time) ([]models.DateRule, error) {
 	var rules []models.DateRule
 
 	// Format tanggal agar konsisten
-	startOfStartDate := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
-	endOfEndDate := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 23, 59, 59, 999999999, endDate.Location())
+	startOfStartDate := models.CalendarDate(startDate)
+	endOfEndDate := models.CalendarDate(endDate).AddDate(0, 0, 1).Add(-time.Nanosecond)
 
 	collection := r.db.Collection("date_rules")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{
 			"date": bson.M{
 				"$gte": startOfStartDate,
@@ -52,58 +78,20 @@ func (r *dateRepository) FindDateRules(startDate, endDate time.Time) ([]models.D
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &rules); err != nil {
+	if err = cursor.All(r.context(), &rules); err != nil {
 		return nil, err
 	}
 
 	return rules, nil
 }
 
-func (r *dateRepository) GetPointCostForDate(date time.Time) (int, error) {
-	// Format tanggal agar hanya menggunakan komponen tanggal (tanpa waktu)
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := time.Date(date.Year(), date.Month(), date.Day(), 23, 59, 59, 999999999, date.Location())
-
-	// Cari aturan khusus untuk tanggal ini
-	var rule models.DateRule
-	collection := r.db.Collection("date_rules")
-
-	err := collection.FindOne(
-		context.Background(),
-		bson.M{
-			"date": bson.M{
-				"$gte": startOfDay,
-				"$lte": endOfDay,
-			},
-		},
-	).Decode(&rule)
-
-	if err == nil {
-		// Rule ditemukan
-		return rule.PointCost, nil
-	}
-
-	if err != mongo.ErrNoDocuments {
-		// Error lain
-		return 0, err
-	}
-
-	// Tidak ada aturan khusus, cek apakah weekend
-	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
-		return 2, nil // Weekend = 2 point
-	}
-
-	// Hari biasa
-	return 1, nil
-}
-
 // Admin functions
 
 func (r *dateRepository) CreateDateRule(rule *models.DateRule) error {
 	collection := r.db.Collection("date_rules")
-	_, err := collection.InsertOne(context.Background(), rule)
+	_, err := collection.InsertOne(r.context(), rule)
 	return err
 }
 
@@ -115,11 +103,14 @@ func (r *dateRepository) UpdateDateRule(rule *models.DateRule) error {
 			"type":       rule.Type,
 			"point_cost": rule.PointCost,
 			"name":       rule.Name,
+			"version":    rule.Version,
+			"updated_by": rule.UpdatedBy,
+			"updated_at": rule.UpdatedAt,
 		},
 	}
 
 	_, err := collection.UpdateOne(
-		context.Background(),
+		r.context(),
 		bson.M{"_id": rule.ID},
 		update,
 	)
@@ -129,6 +120,147 @@ func (r *dateRepository) UpdateDateRule(rule *models.DateRule) error {
 
 func (r *dateRepository) DeleteDateRule(id primitive.ObjectID) error {
 	collection := r.db.Collection("date_rules")
-	_, err := collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	_, err := collection.DeleteOne(r.context(), bson.M{"_id": id})
+	return err
+}
+
+func (r *dateRepository) FindDateRuleByID(id primitive.ObjectID) (*models.DateRule, error) {
+	var rule models.DateRule
+
+	collection := r.db.Collection("date_rules")
+	err := collection.FindOne(r.context(), bson.M{"_id": id}).Decode(&rule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("date rule not found")
+		}
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *dateRepository) FindDateRuleVersions(ruleID primitive.ObjectID) ([]models.DateRuleVersion, error) {
+	var versions []models.DateRuleVersion
+
+	collection := r.db.Collection("date_rule_versions")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{"rule_id": ruleID},
+		options.Find().SetSort(bson.M{"version": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *dateRepository) FindDateRuleVersionsInRange(startDate, endDate time.Time) ([]models.DateRuleVersion, error) {
+	var versions []models.DateRuleVersion
+
+	startOfStartDate := models.CalendarDate(startDate)
+	endOfEndDate := models.CalendarDate(endDate).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	collection := r.db.Collection("date_rule_versions")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{
+			"date": bson.M{
+				"$gte": startOfStartDate,
+				"$lte": endOfEndDate,
+			},
+		},
+		options.Find().SetSort(bson.D{{Key: "changed_at", Value: 1}, {Key: "version", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (r *dateRepository) CreateDateRuleVersion(version *models.DateRuleVersion) error {
+	collection := r.db.Collection("date_rule_versions")
+	_, err := collection.InsertOne(r.context(), version)
+	return err
+}
+
+func (r *dateRepository) FindRecurringRules() ([]models.RecurringDateRule, error) {
+	var rules []models.RecurringDateRule
+
+	collection := r.db.Collection("recurring_date_rules")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{},
+		options.Find().SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "point_cost", Value: -1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *dateRepository) FindRecurringRuleByID(id primitive.ObjectID) (*models.RecurringDateRule, error) {
+	var rule models.RecurringDateRule
+
+	collection := r.db.Collection("recurring_date_rules")
+	err := collection.FindOne(r.context(), bson.M{"_id": id}).Decode(&rule)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("recurring date rule not found")
+		}
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func (r *dateRepository) CreateRecurringRule(rule *models.RecurringDateRule) error {
+	collection := r.db.Collection("recurring_date_rules")
+	_, err := collection.InsertOne(r.context(), rule)
 	return err
 }
+
+func (r *dateRepository) UpdateRecurringRule(rule *models.RecurringDateRule) error {
+	collection := r.db.Collection("recurring_date_rules")
+	result, err := collection.ReplaceOne(r.context(), bson.M{"_id": rule.ID}, rule)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("recurring date rule not found")
+	}
+
+	return nil
+}
+
+func (r *dateRepository) DeleteRecurringRule(id primitive.ObjectID) error {
+	collection := r.db.Collection("recurring_date_rules")
+	result, err := collection.DeleteOne(r.context(), bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("recurring date rule not found")
+	}
+
+	return nil
+}
@@ -120,12 +120,18 @@ func (r *hotelRepository) Update(hotel *models.Hotel) error {
 
 	update := bson.M{
 		"$set": bson.M{
-			"name":        hotel.Name,
-			"description": hotel.Description,
-			"address":     hotel.Address,
-			"city":        hotel.City,
-			"image":       hotel.Image,
-			"updated_at":  hotel.UpdatedAt,
+			"name":                 hotel.Name,
+			"description":          hotel.Description,
+			"address":              hotel.Address,
+			"city":                 hotel.City,
+			"image":                hotel.Image,
+			"point_multiplier":     hotel.PointMultiplier,
+			"weekend_days":         hotel.WeekendDays,
+			"last_minute_discount": hotel.LastMinuteDiscount,
+			"time_zone":            hotel.TimeZone,
+			"check_in_time":        hotel.CheckInTime,
+			"check_out_time":       hotel.CheckOutTime,
+			"updated_at":           hotel.UpdatedAt,
 		},
 	}
 
@@ -157,11 +163,12 @@ func (r *hotelRepository) UpdateRoom(room *models.Room) error {
 
 	update := bson.M{
 		"$set": bson.M{
-			"name":        room.Name,
-			"description": room.Description,
-			"hotel_id":    room.HotelID,
-			"capacity":    room.Capacity,
-			"image":       room.Image,
+			"name":             room.Name,
+			"description":      room.Description,
+			"hotel_id":         room.HotelID,
+			"capacity":         room.Capacity,
+			"image":            room.Image,
+			"point_multiplier": room.PointMultiplier,
 		},
 	}
 
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EnsureIndexes membuat index yang dibutuhkan repository untuk menjaga konsistensi data.
+// Aman dijalankan berulang; index yang sudah ada tidak diubah.
+func EnsureIndexes(db *mongo.Database) error {
+	indexes := map[string][]mongo.IndexModel{
+		// Satu user hanya boleh menjadi anggota satu pool
+		"pools": {
+			{
+				Keys:    bson.D{{Key: "member_ids", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	}
+
+	for collection, models := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(context.Background(), models); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"hotel-point-app/internal/models"
+)
+
+type PointLotRepository interface {
+	Create(lot *models.PointLot) error
+	FindByID(id primitive.ObjectID) (*models.PointLot, error)
+	// FindAvailableByUserID mengembalikan lot yang masih bersisa dan belum lewat expires_at,
+	// urut dari yang paling cepat kedaluwarsa
+	FindAvailableByUserID(userID primitive.ObjectID) ([]models.PointLot, error)
+	// FindDueForExpiry mengembalikan lot bersisa yang sudah melewati tanggal kedaluwarsa
+	FindDueForExpiry(now time.Time) ([]models.PointLot, error)
+	// Consume mengurangi sisa point lot
+	Consume(id primitive.ObjectID, amount int) error
+	// Restore mengembalikan point ke lot, lot yang sudah kedaluwarsa akan diproses ulang oleh job expiry
+	Restore(id primitive.ObjectID, amount int) error
+	MarkExpired(id primitive.ObjectID) error
+
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx, mis. session dari TransactionManager
+	WithContext(ctx context.Context) PointLotRepository
+}
+
+type pointLotRepository struct {
+	db  *mongo.Database
+	ctx context.Context
+}
+
+func NewPointLotRepository(db *mongo.Database) PointLotRepository {
+	return &pointLotRepository{db: db, ctx: context.Background()}
+}
+
+func (r *pointLotRepository) WithContext(ctx context.Context) PointLotRepository {
+	return &pointLotRepository{db: r.db, ctx: ctx}
+}
+
+func (r *pointLotRepository) context() context.Context {
+	return r.ctx
+}
+
+func (r *pointLotRepository) Create(lot *models.PointLot) error {
+	if lot.ID.IsZero() {
+		lot.ID = primitive.NewObjectID()
+	}
+	lot.CreatedAt = time.Now()
+
+	collection := r.db.Collection("point_lots")
+	_, err := collection.InsertOne(r.context(), lot)
+	return err
+}
+
+func (r *pointLotRepository) FindByID(id primitive.ObjectID) (*models.PointLot, error) {
+	var lot models.PointLot
+
+	collection := r.db.Collection("point_lots")
+	err := collection.FindOne(r.context(), bson.M{"_id": id}).Decode(&lot)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("point lot not found")
+		}
+		return nil, err
+	}
+
+	return &lot, nil
+}
+
+func (r *pointLotRepository) FindAvailableByUserID(userID primitive.ObjectID) ([]models.PointLot, error) {
+	return r.find(
+		bson.M{
+			"user_id":   userID,
+			"remaining": bson.M{"$gt": 0},
+			"expired":   false,
+			// Lot yang sudah lewat expires_at tidak bisa dipakai walaupun job kedaluwarsa belum berjalan
+			"expires_at": bson.M{"$gt": time.Now()},
+		},
+	)
+}
+
+func (r *pointLotRepository) FindDueForExpiry(now time.Time) ([]models.PointLot, error) {
+	return r.find(
+		bson.M{
+			"remaining":  bson.M{"$gt": 0},
+			"expired":    false,
+			"expires_at": bson.M{"$lte": now},
+		},
+	)
+}
+
+func (r *pointLotRepository) find(filter bson.M) ([]models.PointLot, error) {
+	var lots []models.PointLot
+
+	collection := r.db.Collection("point_lots")
+	cursor, err := collection.Find(
+		r.context(),
+		filter,
+		options.Find().SetSort(bson.D{{Key: "expires_at", Value: 1}, {Key: "created_at", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &lots); err != nil {
+		return nil, err
+	}
+
+	return lots, nil
+}
+
+func (r *pointLotRepository) Consume(id primitive.ObjectID, amount int) error {
+	collection := r.db.Collection("point_lots")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": id},
+		bson.M{"$inc": bson.M{"remaining": -amount}},
+	)
+	return err
+}
+
+func (r *pointLotRepository) Restore(id primitive.ObjectID, amount int) error {
+	collection := r.db.Collection("point_lots")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": id},
+		bson.M{
+			"$inc": bson.M{"remaining": amount},
+			"$set": bson.M{"expired": false},
+		},
+	)
+	return err
+}
+
+func (r *pointLotRepository) MarkExpired(id primitive.ObjectID) error {
+	collection := r.db.Collection("point_lots")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"remaining": 0, "expired": true}},
+	)
+	return err
+}
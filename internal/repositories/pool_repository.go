@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"hotel-point-app/internal/models"
+)
+
+type PoolRepository interface {
+	Create(pool *models.Pool) error
+	FindByID(id primitive.ObjectID) (*models.Pool, error)
+	FindByJoinCode(joinCode string) (*models.Pool, error)
+	// FindByMemberID mengembalikan nil jika user bukan anggota pool mana pun
+	FindByMemberID(userID primitive.ObjectID) (*models.Pool, error)
+	// AddMemberByJoinCode menambahkan user ke pool dengan joinCode dalam satu update bersyarat.
+	// Index unik member_ids menolak user yang sudah menjadi anggota pool lain.
+	AddMemberByJoinCode(joinCode string, userID primitive.ObjectID) (*models.Pool, error)
+	// RemoveMember mengeluarkan userID dari pool. Anggota terakhir menghapus pool sekaligus,
+	// sehingga pool tidak pernah tersisa tanpa anggota meskipun anggota keluar bersamaan.
+	RemoveMember(id, userID primitive.ObjectID) error
+	// SetShareLimit mengubah batas point userID yang boleh dipakai anggota lain, 0 menghapus persetujuan
+	SetShareLimit(id, userID primitive.ObjectID, maxPoints int) error
+}
+
+type poolRepository struct {
+	db *mongo.Database
+}
+
+func NewPoolRepository(db *mongo.Database) PoolRepository {
+	return &poolRepository{db: db}
+}
+
+func (r *poolRepository) Create(pool *models.Pool) error {
+	if pool.ID.IsZero() {
+		pool.ID = primitive.NewObjectID()
+	}
+	pool.CreatedAt = time.Now()
+	pool.UpdatedAt = time.Now()
+
+	collection := r.db.Collection("pools")
+	_, err := collection.InsertOne(context.Background(), pool)
+	if mongo.IsDuplicateKeyError(err) {
+		return errors.New("user already belongs to a pool")
+	}
+	return err
+}
+
+func (r *poolRepository) FindByID(id primitive.ObjectID) (*models.Pool, error) {
+	return r.findOne(bson.M{"_id": id})
+}
+
+func (r *poolRepository) FindByJoinCode(joinCode string) (*models.Pool, error) {
+	return r.findOne(bson.M{"join_code": joinCode})
+}
+
+func (r *poolRepository) FindByMemberID(userID primitive.ObjectID) (*models.Pool, error) {
+	pool, err := r.findOne(bson.M{"member_ids": userID})
+	if err != nil {
+		if err.Error() == "pool not found" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func (r *poolRepository) AddMemberByJoinCode(joinCode string, userID primitive.ObjectID) (*models.Pool, error) {
+	var pool models.Pool
+
+	collection := r.db.Collection("pools")
+	err := collection.FindOneAndUpdate(
+		context.Background(),
+		bson.M{"join_code": joinCode, "member_ids": bson.M{"$ne": userID}},
+		bson.M{
+			"$addToSet": bson.M{"member_ids": userID},
+			"$set":      bson.M{"updated_at": time.Now()},
+		},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&pool)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errors.New("user already belongs to a pool")
+		}
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("pool not found")
+		}
+		return nil, err
+	}
+
+	return &pool, nil
+}
+
+func (r *poolRepository) RemoveMember(id, userID primitive.ObjectID) error {
+	collection := r.db.Collection("pools")
+	for {
+		// Anggota terakhir menghapus pool
+		deleted, err := collection.DeleteOne(
+			context.Background(),
+			bson.M{"_id": id, "member_ids": bson.M{"$eq": userID, "$size": 1}},
+		)
+		if err != nil {
+			return err
+		}
+		if deleted.DeletedCount > 0 {
+			return nil
+		}
+
+		// Selain itu keluarkan user selama masih ada anggota lain
+		result, err := collection.UpdateOne(
+			context.Background(),
+			bson.M{"_id": id, "member_ids": userID, "member_ids.1": bson.M{"$exists": true}},
+			bson.M{
+				"$pull":  bson.M{"member_ids": userID},
+				"$unset": bson.M{"share_limits." + userID.Hex(): ""},
+				"$set":   bson.M{"updated_at": time.Now()},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		// Tidak ada yang cocok: user sudah keluar, atau jumlah anggota berubah di antara kedua
+		// operasi dan keduanya dicoba lagi
+		count, err := collection.CountDocuments(context.Background(), bson.M{"_id": id, "member_ids": userID})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("pool not found")
+		}
+	}
+}
+
+func (r *poolRepository) SetShareLimit(id, userID primitive.ObjectID, maxPoints int) error {
+	update := bson.M{
+		"$set": bson.M{"share_limits." + userID.Hex(): maxPoints, "updated_at": time.Now()},
+	}
+	if maxPoints == 0 {
+		update = bson.M{
+			"$unset": bson.M{"share_limits." + userID.Hex(): ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	collection := r.db.Collection("pools")
+	result, err := collection.UpdateOne(
+		context.Background(),
+		bson.M{"_id": id, "member_ids": userID},
+		update,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("pool not found")
+	}
+
+	return nil
+}
+
+// findOne mengembalikan pool pertama yang cocok dengan filter
+func (r *poolRepository) findOne(filter bson.M) (*models.Pool, error) {
+	var pool models.Pool
+
+	collection := r.db.Collection("pools")
+	err := collection.FindOne(context.Background(), filter).Decode(&pool)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("pool not found")
+		}
+		return nil, err
+	}
+
+	return &pool, nil
+}
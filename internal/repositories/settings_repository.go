@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"hotel-point-app/internal/models"
+)
+
+type SettingsRepository interface {
+	// Get mengembalikan nil jika pengaturan belum pernah disimpan
+	Get() (*models.Settings, error)
+	// UpdateSection mengubah satu bagian pengaturan (mis. "transfer") dengan $set tanpa menimpa
+	// bagian lain. Jika dokumen belum ada, bagian lain diisi dari defaults.
+	UpdateSection(section string, value interface{}, updatedBy primitive.ObjectID, defaults *models.Settings) (*models.Settings, error)
+}
+
+type settingsRepository struct {
+	db *mongo.Database
+}
+
+func NewSettingsRepository(db *mongo.Database) SettingsRepository {
+	return &settingsRepository{db: db}
+}
+
+func (r *settingsRepository) Get() (*models.Settings, error) {
+	var settings models.Settings
+
+	collection := r.db.Collection("settings")
+	err := collection.FindOne(context.Background(), bson.M{"_id": models.SettingsID}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+func (r *settingsRepository) UpdateSection(section string, value interface{}, updatedBy primitive.ObjectID, defaults *models.Settings) (*models.Settings, error) {
+	data, err := bson.Marshal(defaults)
+	if err != nil {
+		return nil, err
+	}
+	var onInsert bson.M
+	if err := bson.Unmarshal(data, &onInsert); err != nil {
+		return nil, err
+	}
+	// Field yang di-$set tidak boleh muncul lagi di $setOnInsert
+	for _, field := range []string{"_id", section, "updated_by", "updated_at"} {
+		delete(onInsert, field)
+	}
+
+	var settings models.Settings
+	collection := r.db.Collection("settings")
+	err = collection.FindOneAndUpdate(
+		context.Background(),
+		bson.M{"_id": models.SettingsID},
+		bson.M{
+			"$set": bson.M{
+				section:      value,
+				"updated_by": updatedBy,
+				"updated_at": time.Now(),
+			},
+			"$setOnInsert": onInsert,
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"hotel-point-app/internal/models"
+)
+
+type TierRepository interface {
+	FindAll() ([]models.Tier, error)
+	FindByID(id primitive.ObjectID) (*models.Tier, error)
+
+	// Admin functions
+	Create(tier *models.Tier) error
+	Update(tier *models.Tier) error
+	Delete(id primitive.ObjectID) error
+}
+
+type tierRepository struct {
+	db *mongo.Database
+}
+
+func NewTierRepository(db *mongo.Database) TierRepository {
+	return &tierRepository{db: db}
+}
+
+func (r *tierRepository) FindAll() ([]models.Tier, error) {
+	var tiers []models.Tier
+
+	collection := r.db.Collection("tiers")
+	cursor, err := collection.Find(
+		context.Background(),
+		bson.M{},
+		options.Find().SetSort(bson.D{{Key: "min_years_of_service", Value: 1}, {Key: "name", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	if err = cursor.All(context.Background(), &tiers); err != nil {
+		return nil, err
+	}
+
+	return tiers, nil
+}
+
+func (r *tierRepository) FindByID(id primitive.ObjectID) (*models.Tier, error) {
+	var tier models.Tier
+
+	collection := r.db.Collection("tiers")
+	err := collection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&tier)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("tier not found")
+		}
+		return nil, err
+	}
+
+	return &tier, nil
+}
+
+func (r *tierRepository) Create(tier *models.Tier) error {
+	collection := r.db.Collection("tiers")
+	_, err := collection.InsertOne(context.Background(), tier)
+	return err
+}
+
+func (r *tierRepository) Update(tier *models.Tier) error {
+	collection := r.db.Collection("tiers")
+	result, err := collection.ReplaceOne(context.Background(), bson.M{"_id": tier.ID}, tier)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("tier not found")
+	}
+
+	return nil
+}
+
+func (r *tierRepository) Delete(id primitive.ObjectID) error {
+	collection := r.db.Collection("tiers")
+	result, err := collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("tier not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TransactionManager menjalankan beberapa operasi repository sebagai satu unit kerja.
+// Repository yang ikut dalam transaksi harus diambil lewat WithContext(ctx) dengan
+// ctx yang diberikan ke fn. Transaksi MongoDB membutuhkan replica set atau mongos.
+type TransactionManager interface {
+	WithTransaction(fn func(ctx context.Context) error) error
+}
+
+type transactionManager struct {
+	db *mongo.Database
+}
+
+func NewTransactionManager(db *mongo.Database) TransactionManager {
+	return &transactionManager{db: db}
+}
+
+func (m *transactionManager) WithTransaction(fn func(ctx context.Context) error) error {
+	session, err := m.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	// WithTransaction mengulang fn jika terjadi TransientTransactionError,
+	// sehingga fn harus aman untuk dijalankan lebih dari sekali
+	_, err = session.WithTransaction(context.Background(), func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
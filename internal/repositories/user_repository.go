@@ -13,22 +13,62 @@ import (
 	"hotel-point-app/internal/models"
 )
 
+// PointHistoryFilter membatasi transaksi yang dikembalikan FindPointHistory, field kosong diabaikan
+type PointHistoryFilter struct {
+	StartDate time.Time // Inklusif, mulai hari tersebut
+	EndDate   time.Time // Inklusif, sampai akhir hari tersebut
+	Types     []string
+}
+
 type UserRepository interface {
 	Create(user *models.User) error
 	FindByID(id primitive.ObjectID) (*models.User, error)
 	FindByEmail(email string) (*models.User, error)
+	FindAll() ([]models.User, error)
 	Update(user *models.User) error
+	UpdateGrade(userID primitive.ObjectID, grade string) error
+	UpdateJoinedAt(userID primitive.ObjectID, joinedAt time.Time) error
 	UpdatePointBalance(userID primitive.ObjectID, points int) error
+	// UpdateHeldPoints menambah (atau mengurangi jika negatif) point yang ditahan booking pending
+	UpdateHeldPoints(userID primitive.ObjectID, points int) error
 	CreatePointTransaction(transaction *models.PointTransaction) error
 	GetPointTransactions(userID primitive.ObjectID) ([]models.PointTransaction, error)
+	// FindPointHistory mengembalikan transaksi terbaru lebih dulu beserta total yang cocok dengan filter.
+	// limit <= 0 mengembalikan semua transaksi.
+	FindPointHistory(userID primitive.ObjectID, filter PointHistoryFilter, skip, limit int) ([]models.PointHistoryEntry, int, error)
+	// FindLatestPointTransaction mengembalikan nil jika tidak ada transaksi yang cocok
+	FindLatestPointTransaction(userID primitive.ObjectID, transactionTypes []string, reference string) (*models.PointTransaction, error)
+	// SumPointTransactionsByReference menjumlahkan transaksi user bertipe transactionTypes dengan reference
+	SumPointTransactionsByReference(userID primitive.ObjectID, transactionTypes []string, reference string) (int, error)
+	// LockPointGrant menulis penanda grant user untuk reference di dalam transaksi sehingga
+	// grant lain untuk user dan reference yang sama bentrok (write conflict) dan diulang
+	LockPointGrant(userID primitive.ObjectID, reference string) error
+	SumPointTransactions(userID primitive.ObjectID) (int, error)
+	SumPointTransactionsByUser() (map[primitive.ObjectID]int, error)
+	// SumPointTransactionsBefore menjumlahkan transaksi user sebelum before, yaitu saldo ledger pada saat itu
+	SumPointTransactionsBefore(userID primitive.ObjectID, before time.Time) (int, error)
+	// SumPointTransactionsByType menjumlahkan transaksi bertipe transactionType sejak since
+	SumPointTransactionsByType(userID primitive.ObjectID, transactionType string, since time.Time) (int, error)
+
+	// WithContext mengembalikan repository yang menjalankan query dengan ctx, mis. session dari TransactionManager
+	WithContext(ctx context.Context) UserRepository
 }
 
 type userRepository struct {
-	db *mongo.Database
+	db  *mongo.Database
+	ctx context.Context
 }
 
 func NewUserRepository(db *mongo.Database) UserRepository {
-	return &userRepository{db: db}
+	return &userRepository{db: db, ctx: context.Background()}
+}
+
+func (r *userRepository) WithContext(ctx context.Context) UserRepository {
+	return &userRepository{db: r.db, ctx: ctx}
+}
+
+func (r *userRepository) context() context.Context {
+	return r.ctx
 }
 
 func (r *userRepository) Create(user *models.User) error {
@@ -36,14 +76,14 @@ func (r *userRepository) Create(user *models.User) error {
 	user.UpdatedAt = time.Now()
 
 	collection := r.db.Collection("users")
-	_, err := collection.InsertOne(context.Background(), user)
+	_, err := collection.InsertOne(r.context(), user)
 	return err
 }
 
 func (r *userRepository) FindByID(id primitive.ObjectID) (*models.User, error) {
 	var user models.User
 	collection := r.db.Collection("users")
-	err := collection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&user)
+	err := collection.FindOne(r.context(), bson.M{"_id": id}).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errors.New("user not found")
@@ -56,7 +96,7 @@ func (r *userRepository) FindByID(id primitive.ObjectID) (*models.User, error) {
 func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 	var user models.User
 	collection := r.db.Collection("users")
-	err := collection.FindOne(context.Background(), bson.M{"email": email}).Decode(&user)
+	err := collection.FindOne(r.context(), bson.M{"email": email}).Decode(&user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, errors.New("user not found")
@@ -66,22 +106,63 @@ func (r *userRepository) FindByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+func (r *userRepository) FindAll() ([]models.User, error) {
+	var users []models.User
+
+	collection := r.db.Collection("users")
+	cursor, err := collection.Find(
+		r.context(),
+		bson.M{},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	if err = cursor.All(r.context(), &users); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 func (r *userRepository) Update(user *models.User) error {
 	user.UpdatedAt = time.Now()
 
 	collection := r.db.Collection("users")
 	_, err := collection.UpdateOne(
-		context.Background(),
+		r.context(),
 		bson.M{"_id": user.ID},
 		bson.M{"$set": user},
 	)
 	return err
 }
 
+func (r *userRepository) UpdateGrade(userID primitive.ObjectID, grade string) error {
+	collection := r.db.Collection("users")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"grade": grade, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+func (r *userRepository) UpdateJoinedAt(userID primitive.ObjectID, joinedAt time.Time) error {
+	collection := r.db.Collection("users")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"joined_at": joinedAt, "updated_at": time.Now()}},
+	)
+	return err
+}
+
 func (r *userRepository) UpdatePointBalance(userID primitive.ObjectID, points int) error {
 	collection := r.db.Collection("users")
 	_, err := collection.UpdateOne(
-		context.Background(),
+		r.context(),
 		bson.M{"_id": userID},
 		bson.M{
 			"$inc": bson.M{"point_balance": points},
@@ -91,11 +172,24 @@ func (r *userRepository) UpdatePointBalance(userID primitive.ObjectID, points in
 	return err
 }
 
+func (r *userRepository) UpdateHeldPoints(userID primitive.ObjectID, points int) error {
+	collection := r.db.Collection("users")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": userID},
+		bson.M{
+			"$inc": bson.M{"held_points": points},
+			"$set": bson.M{"updated_at": time.Now()},
+		},
+	)
+	return err
+}
+
 func (r *userRepository) CreatePointTransaction(transaction *models.PointTransaction) error {
 	transaction.CreatedAt = time.Now()
 
 	collection := r.db.Collection("point_transactions")
-	_, err := collection.InsertOne(context.Background(), transaction)
+	_, err := collection.InsertOne(r.context(), transaction)
 	return err
 }
 
@@ -104,18 +198,202 @@ func (r *userRepository) GetPointTransactions(userID primitive.ObjectID) ([]mode
 
 	collection := r.db.Collection("point_transactions")
 	cursor, err := collection.Find(
-		context.Background(),
+		r.context(),
 		bson.M{"user_id": userID},
 		options.Find().SetSort(bson.M{"created_at": -1}),
 	)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	defer cursor.Close(r.context())
 
-	if err = cursor.All(context.Background(), &transactions); err != nil {
+	if err = cursor.All(r.context(), &transactions); err != nil {
 		return nil, err
 	}
 
 	return transactions, nil
 }
+
+func (r *userRepository) FindPointHistory(userID primitive.ObjectID, filter PointHistoryFilter, skip, limit int) ([]models.PointHistoryEntry, int, error) {
+	match := bson.M{}
+	if !filter.StartDate.IsZero() || !filter.EndDate.IsZero() {
+		createdAt := bson.M{}
+		if !filter.StartDate.IsZero() {
+			createdAt["$gte"] = time.Date(filter.StartDate.Year(), filter.StartDate.Month(), filter.StartDate.Day(), 0, 0, 0, 0, filter.StartDate.Location())
+		}
+		if !filter.EndDate.IsZero() {
+			createdAt["$lte"] = time.Date(filter.EndDate.Year(), filter.EndDate.Month(), filter.EndDate.Day(), 23, 59, 59, 999999999, filter.EndDate.Location())
+		}
+		match["created_at"] = createdAt
+	}
+	if len(filter.Types) > 0 {
+		match["type"] = bson.M{"$in": filter.Types}
+	}
+
+	page := mongo.Pipeline{{{Key: "$skip", Value: skip}}}
+	if limit > 0 {
+		page = append(page, bson.D{{Key: "$limit", Value: limit}})
+	}
+
+	collection := r.db.Collection("point_transactions")
+	cursor, err := collection.Aggregate(r.context(), mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		// Saldo berjalan dihitung dari seluruh transaksi user sebelum filter diterapkan
+		{{Key: "$setWindowFields", Value: bson.M{
+			"sortBy": bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}},
+			"output": bson.M{
+				"balance_after": bson.M{
+					"$sum":   "$amount",
+					"window": bson.M{"documents": bson.A{"unbounded", "current"}},
+				},
+			},
+		}}},
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"entries": page,
+			"total":   bson.A{bson.M{"$count": "count"}},
+		}}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(r.context())
+
+	var results []struct {
+		Entries []models.PointHistoryEntry `bson:"entries"`
+		Total   []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(r.context(), &results); err != nil {
+		return nil, 0, err
+	}
+
+	if len(results) == 0 || len(results[0].Total) == 0 {
+		return []models.PointHistoryEntry{}, 0, nil
+	}
+
+	return results[0].Entries, results[0].Total[0].Count, nil
+}
+
+func (r *userRepository) SumPointTransactions(userID primitive.ObjectID) (int, error) {
+	sums, err := r.sumPointTransactions(bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+
+	return sums[userID], nil
+}
+
+func (r *userRepository) SumPointTransactionsByUser() (map[primitive.ObjectID]int, error) {
+	return r.sumPointTransactions(bson.M{})
+}
+
+func (r *userRepository) SumPointTransactionsByReference(userID primitive.ObjectID, transactionTypes []string, reference string) (int, error) {
+	sums, err := r.sumPointTransactions(bson.M{
+		"user_id":   userID,
+		"type":      bson.M{"$in": transactionTypes},
+		"reference": reference,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sums[userID], nil
+}
+
+func (r *userRepository) SumPointTransactionsBefore(userID primitive.ObjectID, before time.Time) (int, error) {
+	sums, err := r.sumPointTransactions(bson.M{
+		"user_id":    userID,
+		"created_at": bson.M{"$lt": before},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sums[userID], nil
+}
+
+func (r *userRepository) SumPointTransactionsByType(userID primitive.ObjectID, transactionType string, since time.Time) (int, error) {
+	sums, err := r.sumPointTransactions(bson.M{
+		"user_id":    userID,
+		"type":       transactionType,
+		"created_at": bson.M{"$gte": since},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sums[userID], nil
+}
+
+// sumPointTransactions menjumlahkan amount transaksi per user yang cocok dengan filter
+func (r *userRepository) sumPointTransactions(filter bson.M) (map[primitive.ObjectID]int, error) {
+	collection := r.db.Collection("point_transactions")
+	cursor, err := collection.Aggregate(r.context(), mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id", "total": bson.M{"$sum": "$amount"}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(r.context())
+
+	var results []struct {
+		UserID primitive.ObjectID `bson:"_id"`
+		Total  int                `bson:"total"`
+	}
+	if err = cursor.All(r.context(), &results); err != nil {
+		return nil, err
+	}
+
+	sums := make(map[primitive.ObjectID]int, len(results))
+	for _, result := range results {
+		sums[result.UserID] = result.Total
+	}
+
+	return sums, nil
+}
+
+func (r *userRepository) FindLatestPointTransaction(userID primitive.ObjectID, transactionTypes []string, reference string) (*models.PointTransaction, error) {
+	var transaction models.PointTransaction
+
+	collection := r.db.Collection("point_transactions")
+	err := collection.FindOne(
+		r.context(),
+		bson.M{
+			"user_id":   userID,
+			"type":      bson.M{"$in": transactionTypes},
+			"reference": reference,
+		},
+		options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}),
+	).Decode(&transaction)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+func (r *userRepository) LockPointGrant(userID primitive.ObjectID, reference string) error {
+	// _id gabungan user dan reference membuat satu penanda per periode tanpa index tambahan.
+	// updated_at selalu ditulis agar dua grant bersamaan bentrok di dokumen yang sama.
+	collection := r.db.Collection("point_grants")
+	_, err := collection.UpdateOne(
+		r.context(),
+		bson.M{"_id": userID.Hex() + ":" + reference},
+		bson.M{
+			"$set": bson.M{"updated_at": time.Now()},
+			"$setOnInsert": bson.M{
+				"user_id":   userID,
+				"reference": reference,
+			},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"hotel-point-app/internal/models"
+)
+
+type YearEndRepository interface {
+	// FindClosure mengembalikan nil jika tahun tersebut belum ditutup
+	FindClosure(year int) (*models.YearEndClosure, error)
+	CreateClosure(closure *models.YearEndClosure) error
+}
+
+type yearEndRepository struct {
+	db *mongo.Database
+}
+
+func NewYearEndRepository(db *mongo.Database) YearEndRepository {
+	return &yearEndRepository{db: db}
+}
+
+func (r *yearEndRepository) FindClosure(year int) (*models.YearEndClosure, error) {
+	var closure models.YearEndClosure
+
+	collection := r.db.Collection("year_end_closures")
+	err := collection.FindOne(context.Background(), bson.M{"_id": year}).Decode(&closure)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &closure, nil
+}
+
+func (r *yearEndRepository) CreateClosure(closure *models.YearEndClosure) error {
+	collection := r.db.Collection("year_end_closures")
+	_, err := collection.InsertOne(context.Background(), closure)
+	return err
+}
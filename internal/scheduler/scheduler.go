@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job adalah pekerjaan latar belakang yang dijalankan secara berkala
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+// Scheduler menjalankan job berkala sampai context dibatalkan
+type Scheduler struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+// New membuat scheduler baru tanpa job
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add mendaftarkan job, harus dipanggil sebelum Start
+func (s *Scheduler) Add(job Job) {
+	if job.Interval <= 0 {
+		job.Interval = time.Hour
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start menjalankan setiap job sekali di awal lalu setiap Interval
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Wait menunggu semua job berhenti setelah context dibatalkan
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		runJob(job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runJob(job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(); err != nil {
+		log.Printf("Job %s failed: %v", job.Name, err)
+	}
+}
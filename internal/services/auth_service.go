@@ -14,6 +14,8 @@ import (
 )
 
 type AuthService interface {
+	// Register membuat user baru tanpa tanggal mulai bekerja, sehingga user mendapat grant penuh
+	// periode berjalan. Tanggal mulai bekerja hanya bisa diatur admin lewat GrantService.SetUserJoinedAt.
 	Register(name, email, password string) error
 	Login(email, password string) (string, error)
 	ValidateToken(tokenString string) (*TokenClaims, error)
@@ -26,16 +28,18 @@ type TokenClaims struct {
 }
 
 type authService struct {
-	userRepo  repositories.UserRepository
-	jwtSecret string
-	jwtExpiry int
+	userRepo     repositories.UserRepository
+	grantService GrantService
+	jwtSecret    string
+	jwtExpiry    int
 }
 
-func NewAuthService(userRepo repositories.UserRepository, jwtSecret string, jwtExpiry int) AuthService {
+func NewAuthService(userRepo repositories.UserRepository, grantService GrantService, jwtSecret string, jwtExpiry int) AuthService {
 	return &authService{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
-		jwtExpiry: jwtExpiry,
+		userRepo:     userRepo,
+		grantService: grantService,
+		jwtSecret:    jwtSecret,
+		jwtExpiry:    jwtExpiry,
 	}
 }
 
@@ -54,29 +58,15 @@ func (s *authService) Register(name, email, password string) error {
 
 	// Create new user
 	user := &models.User{
-		ID:           primitive.NewObjectID(),
-		Name:         name,
-		Email:        email,
-		Password:     string(hashedPassword),
-		PointBalance: 24, // Initial point balance (24 points per year)
+		ID:       primitive.NewObjectID(),
+		Name:     name,
+		Email:    email,
+		Password: string(hashedPassword),
 	}
 
-	// Save user
-	if err := s.userRepo.Create(user); err != nil {
-		return err
-	}
-
-	// Create initial point transaction
-	transaction := &models.PointTransaction{
-		ID:        primitive.NewObjectID(),
-		UserID:    user.ID,
-		Amount:    24,
-		Type:      "annual_grant",
-		Reference: "initial",
-		CreatedAt: time.Now(),
-	}
-
-	return s.userRepo.CreatePointTransaction(transaction)
+	// Simpan user sekaligus berikan point periode berjalan, job grant akan melewati user ini
+	// untuk periode yang sama. Admin yang mengatur tanggal mulai bekerja kemudian hanya menambah kekurangannya.
+	return s.grantService.CreateUserWithGrant(user, s.grantService.CurrentPeriod(time.Now()))
 }
 
 func (s *authService) Login(email, password string) (string, error) {
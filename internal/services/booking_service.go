@@ -2,22 +2,31 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"log"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"hotel-point-app/internal/models"
 	"hotel-point-app/internal/repositories"
+	"hotel-point-app/pkg/jwt"
 )
 
-// DailyPointDetail godoc
-// @Description Detail biaya point per hari dalam pemesanan
-type DailyPointDetail struct {
-	Date      time.Time // Tanggal
-	DayType   string    // Tipe hari (regular, weekend, holiday)
-	PointCost int       // Biaya point
-	Name      string    // Nama hari libur (jika ada)
+// PoolPayment meminta booking dibayar bersama oleh anggota pool pemesan
+type PoolPayment struct {
+	// Shares adalah bagian point tiap anggota. Jika kosong, point pemesan dipakai lebih dulu
+	// lalu sisanya diambil dari anggota lain sesuai urutan bergabung.
+	Shares []models.BookingShare
+}
+
+// BookingQuote adalah hasil perhitungan biaya beserta quote bertanda tangan yang dapat
+// dipakai saat membuat booking agar biaya tidak berubah sampai ExpiresAt
+type BookingQuote struct {
+	*StayQuote
+	Token     string
+	ExpiresAt time.Time
 }
 
 // BookingService godoc
@@ -35,26 +44,39 @@ type BookingService interface {
 
 	// CalculatePointCostWithDetails godoc
 	// @Summary Menghitung biaya point dengan detail harian
-	// @Description Menghitung biaya point dengan rincian per hari
+	// @Description Menghitung biaya point dengan rincian per hari dan potongan yang berlaku
 	// @Param roomID primitive.ObjectID - ID kamar yang akan dipesan
 	// @Param checkIn time.Time - Tanggal check-in
 	// @Param checkOut time.Time - Tanggal check-out
-	// @Return int - Total biaya point
-	// @Return []DailyPointDetail - Detail biaya per hari
+	// @Return *StayQuote - Total biaya point, potongan dan detail biaya per hari
 	// @Return error - nil jika berhasil, error jika gagal
-	CalculatePointCostWithDetails(roomID primitive.ObjectID, checkIn, checkOut time.Time) (int, []DailyPointDetail, error)
+	CalculatePointCostWithDetails(roomID primitive.ObjectID, checkIn, checkOut time.Time) (*StayQuote, error)
+
+	// IssueQuote godoc
+	// @Summary Membuat quote harga bertanda tangan
+	// @Description Menghitung biaya point seperti CalculatePointCostWithDetails dan menandatanganinya untuk user
+	// @Param userID primitive.ObjectID - ID user yang meminta quote
+	// @Param roomID primitive.ObjectID - ID kamar yang akan dipesan
+	// @Param checkIn time.Time - Tanggal check-in
+	// @Param checkOut time.Time - Tanggal check-out
+	// @Return *BookingQuote - Biaya point, token quote dan masa berlakunya
+	// @Return error - nil jika berhasil, error jika gagal
+	IssueQuote(userID, roomID primitive.ObjectID, checkIn, checkOut time.Time) (*BookingQuote, error)
 
 	// CreateBooking godoc
 	// @Summary Membuat pemesanan baru
-	// @Description Membuat pemesanan kamar baru dan mengurangi point user
+	// @Description Membuat pemesanan kamar baru dan mengurangi point user.
+	// @Description Jika persetujuan diperlukan, pemesanan berstatus pending dan point hanya ditahan.
 	// @Param userID primitive.ObjectID - ID user yang memesan
 	// @Param hotelID primitive.ObjectID - ID hotel
 	// @Param roomID primitive.ObjectID - ID kamar
 	// @Param checkIn time.Time - Tanggal check-in
 	// @Param checkOut time.Time - Tanggal check-out
+	// @Param pool *PoolPayment - Pembayaran bersama pool, nil jika dibayar sendiri
+	// @Param quoteToken string - Token dari IssueQuote, jika diisi booking dikenai biaya quote
 	// @Return *models.Booking - Data pemesanan yang dibuat
 	// @Return error - nil jika berhasil, error jika gagal
-	CreateBooking(userID, hotelID, roomID primitive.ObjectID, checkIn, checkOut time.Time) (*models.Booking, error)
+	CreateBooking(userID, hotelID, roomID primitive.ObjectID, checkIn, checkOut time.Time, pool *PoolPayment, quoteToken string) (*models.Booking, error)
 
 	// GetBookingByID godoc
 	// @Summary Mendapatkan detail pemesanan
@@ -74,7 +96,7 @@ type BookingService interface {
 
 	// CancelBooking godoc
 	// @Summary Membatalkan pemesanan
-	// @Description Membatalkan pemesanan dan mengembalikan point
+	// @Description Membatalkan pemesanan dan mengembalikan point ke setiap pembayar sesuai bagiannya
 	// @Param id primitive.ObjectID - ID pemesanan
 	// @Param userID primitive.ObjectID - ID user yang membatalkan
 	// @Return error - nil jika berhasil, error jika gagal
@@ -137,6 +159,14 @@ type BookingService interface {
 	// @Return []models.Booking - Daftar pemesanan aktif
 	// @Return error - nil jika berhasil, error jika gagal
 	GetActiveBookingsByUser(userID primitive.ObjectID) ([]models.Booking, error)
+
+	// ExpirePendingBookings godoc
+	// @Summary Membatalkan pemesanan pending yang kedaluwarsa
+	// @Description Membatalkan pemesanan pending yang melewati batas persetujuan dan melepas point yang ditahan
+	// @Param now time.Time - Waktu acuan
+	// @Return int - Jumlah pemesanan yang dibatalkan
+	// @Return error - nil jika berhasil, error jika gagal
+	ExpirePendingBookings(now time.Time) (int, error)
 }
 
 // bookingService godoc
@@ -145,160 +175,219 @@ type bookingService struct {
 	bookingRepo  repositories.BookingRepository
 	userRepo     repositories.UserRepository
 	hotelRepo    repositories.HotelRepository
-	dateService  DateService
+	txManager    repositories.TransactionManager
+	ledger       *pointLedger
+	pricing      PricingService
 	pointService PointService
+	tierService  TierService
+	poolService  PoolService
+
+	approvalRequired bool          // Booking baru berstatus pending dan hanya menahan point
+	holdDuration     time.Duration // Lama point ditahan menunggu persetujuan
+	quoteSecret      string        // Kunci tanda tangan quote harga
+	quoteDuration    time.Duration // Lama quote harga berlaku
 }
 
 func NewBookingService(
 	bookingRepo repositories.BookingRepository,
 	userRepo repositories.UserRepository,
+	lotRepo repositories.PointLotRepository,
 	hotelRepo repositories.HotelRepository,
-	dateService DateService,
+	txManager repositories.TransactionManager,
+	pricing PricingService,
 	pointService PointService,
+	tierService TierService,
+	poolService PoolService,
+	approvalRequired bool,
+	holdDuration time.Duration,
+	quoteSecret string,
+	quoteDuration time.Duration,
 ) BookingService {
 	return &bookingService{
-		bookingRepo:  bookingRepo,
-		userRepo:     userRepo,
-		hotelRepo:    hotelRepo,
-		dateService:  dateService,
-		pointService: pointService,
+		bookingRepo:      bookingRepo,
+		userRepo:         userRepo,
+		hotelRepo:        hotelRepo,
+		txManager:        txManager,
+		ledger:           newPointLedger(userRepo, lotRepo),
+		pricing:          pricing,
+		pointService:     pointService,
+		tierService:      tierService,
+		poolService:      poolService,
+		approvalRequired: approvalRequired,
+		holdDuration:     holdDuration,
+		quoteSecret:      quoteSecret,
+		quoteDuration:    quoteDuration,
 	}
 }
 
 // Core booking operations
 
 func (s *bookingService) CalculatePointCost(roomID primitive.ObjectID, checkIn, checkOut time.Time) (int, error) {
-	// Standardize the time component
-	startDate := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, checkIn.Location())
-	endDate := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 0, 0, 0, 0, checkOut.Location())
+	quote, err := s.CalculatePointCostWithDetails(roomID, checkIn, checkOut)
+	if err != nil {
+		return 0, err
+	}
+	return quote.TotalPoints, nil
+}
+
+func (s *bookingService) CalculatePointCostWithDetails(roomID primitive.ObjectID, checkIn, checkOut time.Time) (*StayQuote, error) {
+	// checkIn dan checkOut adalah tanggal kalender di zona waktu hotel
+	startDate := models.CalendarDate(checkIn)
+	endDate := models.CalendarDate(checkOut)
 
 	// Validate input
 	if startDate.After(endDate) {
-		return 0, errors.New("check-in date cannot be after check-out date")
-	}
-
-	if startDate.Before(time.Now().AddDate(0, 0, -1)) {
-		return 0, errors.New("check-in date cannot be in the past")
+		return nil, errors.New("check-in date cannot be after check-out date")
 	}
 
 	// Verify room exists
-	_, err := s.hotelRepo.FindRoomByID(roomID)
+	room, err := s.hotelRepo.FindRoomByID(roomID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	// Check room availability
-	available, err := s.bookingRepo.CheckRoomAvailability(roomID, startDate, endDate)
+	// The hotel's multiplier applies to all of its rooms
+	hotel, err := s.hotelRepo.FindByID(room.HotelID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	if !available {
-		return 0, errors.New("room is not available for the selected dates")
+	if err := s.checkStayAvailability(hotel, roomID, startDate, endDate); err != nil {
+		return nil, err
 	}
 
-	// Calculate total point cost
-	totalPoints := 0
+	return s.pricing.QuoteStay(hotel, room, startDate, endDate)
+}
 
-	// Iterate through each day in the booking period
-	for d := startDate; d.Before(endDate); d = d.AddDate(0, 0, 1) {
-		pointCost, err := s.dateService.GetPointCostForDate(d)
-		if err != nil {
-			return 0, err
-		}
-		totalPoints += pointCost
+// checkStayAvailability menolak check-in sebelum hari ini di hotel dan memeriksa bahwa kamar
+// tidak dipesan antara jam check-in dan check-out hotel, sehingga check-out dan check-in
+// di hari yang sama tidak bentrok
+func (s *bookingService) checkStayAvailability(hotel *models.Hotel, roomID primitive.ObjectID, startDate, endDate time.Time) error {
+	if startDate.Before(hotel.Today()) {
+		return errors.New("check-in date cannot be in the past")
+	}
+
+	available, err := s.bookingRepo.CheckRoomAvailability(roomID, hotel.CheckInAt(startDate), hotel.CheckOutAt(endDate))
+	if err != nil {
+		return err
 	}
 
-	return totalPoints, nil
+	if !available {
+		return errors.New("room is not available for the selected dates")
+	}
+
+	return nil
 }
 
-func (s *bookingService) CalculatePointCostWithDetails(roomID primitive.ObjectID, checkIn, checkOut time.Time) (int, []DailyPointDetail, error) {
-	// Standardize the time component
-	startDate := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, checkIn.Location())
-	endDate := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 0, 0, 0, 0, checkOut.Location())
+func (s *bookingService) IssueQuote(userID, roomID primitive.ObjectID, checkIn, checkOut time.Time) (*BookingQuote, error) {
+	quote, err := s.CalculatePointCostWithDetails(roomID, checkIn, checkOut)
+	if err != nil {
+		return nil, err
+	}
 
-	// Validate input
-	if startDate.After(endDate) {
-		return 0, nil, errors.New("check-in date cannot be after check-out date")
+	room, err := s.hotelRepo.FindRoomByID(roomID)
+	if err != nil {
+		return nil, err
 	}
 
-	if startDate.Before(time.Now().AddDate(0, 0, -1)) {
-		return 0, nil, errors.New("check-in date cannot be in the past")
+	claims := &jwt.QuoteClaims{
+		UserID:    userID.Hex(),
+		HotelID:   room.HotelID.Hex(),
+		RoomID:    roomID.Hex(),
+		CheckIn:   checkIn.Format("2006-01-02"),
+		CheckOut:  checkOut.Format("2006-01-02"),
+		PointCost: quote.TotalPoints,
+	}
+	for _, night := range quote.Nights {
+		quoteNight := jwt.QuoteNight{
+			Date:           night.Date.Format("2006-01-02"),
+			DayType:        night.DayType,
+			Name:           night.Name,
+			PointCost:      night.PointCost,
+			DiscountPoints: night.DiscountPoints,
+		}
+		if night.RuleID != nil {
+			quoteNight.RuleID = night.RuleID.Hex()
+		}
+		claims.Nights = append(claims.Nights, quoteNight)
+	}
+	for _, discount := range quote.Discounts {
+		claims.Discounts = append(claims.Discounts, jwt.QuoteDiscount{
+			Type:        discount.Type,
+			Description: discount.Description,
+			Points:      discount.Points,
+		})
 	}
 
-	// Verify room exists
-	_, err := s.hotelRepo.FindRoomByID(roomID)
+	expiresAt := time.Now().Add(s.quoteDuration)
+	token, err := jwt.GenerateQuoteToken(claims, s.quoteSecret, expiresAt)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	// Check room availability
-	available, err := s.bookingRepo.CheckRoomAvailability(roomID, startDate, endDate)
+	return &BookingQuote{StayQuote: quote, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// redeemQuote memvalidasi quote untuk booking ini dan mengembalikan biaya yang dikunci.
+// Kamar tetap harus tersedia.
+func (s *bookingService) redeemQuote(quoteToken string, userID primitive.ObjectID, hotel *models.Hotel, roomID primitive.ObjectID, checkIn, checkOut time.Time) (*StayQuote, error) {
+	claims, err := jwt.ValidateQuoteToken(quoteToken, s.quoteSecret)
 	if err != nil {
-		return 0, nil, err
+		return nil, errors.New("quote is invalid or has expired")
 	}
 
-	if !available {
-		return 0, nil, errors.New("room is not available for the selected dates")
+	startDate := models.CalendarDate(checkIn)
+	endDate := models.CalendarDate(checkOut)
+
+	if claims.UserID != userID.Hex() || claims.HotelID != hotel.ID.Hex() || claims.RoomID != roomID.Hex() ||
+		claims.CheckIn != startDate.Format("2006-01-02") || claims.CheckOut != endDate.Format("2006-01-02") {
+		return nil, errors.New("quote does not match the booking")
 	}
 
-	// Calculate total point cost
-	totalPoints := 0
-	var dailyDetails []DailyPointDetail
+	if err := s.checkStayAvailability(hotel, roomID, startDate, endDate); err != nil {
+		return nil, err
+	}
 
-	// Get date rules for the entire range
-	dateRules, err := s.dateService.GetDateRules(startDate, endDate)
-	if err != nil {
-		return 0, nil, err
-	}
-
-	// Create map of special dates for quick lookup
-	specialDates := make(map[string]models.DateRule)
-	for _, rule := range dateRules {
-		dateKey := rule.Date.Format("2006-01-02")
-		specialDates[dateKey] = rule
-	}
-
-	// Iterate through each day in the booking period
-	for d := startDate; d.Before(endDate); d = d.AddDate(0, 0, 1) {
-		dateKey := d.Format("2006-01-02")
-		var dayType string
-		var pointCost int
-		var name string
-
-		// Check if this date has a special rule
-		if rule, exists := specialDates[dateKey]; exists {
-			dayType = rule.Type
-			pointCost = rule.PointCost
-			name = rule.Name
-		} else {
-			// Default rules based on weekday
-			if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
-				dayType = "weekend"
-				pointCost = 2 // Weekend costs 2 points
-			} else {
-				dayType = "regular"
-				pointCost = 1 // Regular day costs 1 point
+	quote := &StayQuote{TotalPoints: claims.PointCost}
+	for _, night := range claims.Nights {
+		date, err := time.Parse("2006-01-02", night.Date)
+		if err != nil {
+			return nil, errors.New("quote is invalid or has expired")
+		}
+
+		detail := DailyPointDetail{
+			Date:           date,
+			DayType:        night.DayType,
+			Name:           night.Name,
+			PointCost:      night.PointCost,
+			DiscountPoints: night.DiscountPoints,
+		}
+		if night.RuleID != "" {
+			ruleID, err := primitive.ObjectIDFromHex(night.RuleID)
+			if err != nil {
+				return nil, errors.New("quote is invalid or has expired")
 			}
+			detail.RuleID = &ruleID
 		}
 
-		// Add to total and daily details
-		totalPoints += pointCost
-		dailyDetails = append(dailyDetails, DailyPointDetail{
-			Date:      d,
-			DayType:   dayType,
-			PointCost: pointCost,
-			Name:      name,
+		quote.Subtotal += night.PointCost
+		quote.Nights = append(quote.Nights, detail)
+	}
+	for _, discount := range claims.Discounts {
+		quote.Discounts = append(quote.Discounts, models.BookingDiscount{
+			Type:        discount.Type,
+			Description: discount.Description,
+			Points:      discount.Points,
 		})
 	}
 
-	return totalPoints, dailyDetails, nil
+	return quote, nil
 }
 
-func (s *bookingService) CreateBooking(userID, hotelID, roomID primitive.ObjectID, checkIn, checkOut time.Time) (*models.Booking, error) {
-	// Standardize the time component
-	startDate := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 14, 0, 0, 0, checkIn.Location())   // Check-in at 2 PM
-	endDate := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 12, 0, 0, 0, checkOut.Location()) // Check-out at 12 PM
+func (s *bookingService) CreateBooking(userID, hotelID, roomID primitive.ObjectID, checkIn, checkOut time.Time, pool *PoolPayment, quoteToken string) (*models.Booking, error) {
+	// checkIn dan checkOut adalah tanggal kalender di zona waktu hotel
+	startDate := models.CalendarDate(checkIn)
+	endDate := models.CalendarDate(checkOut)
 
 	// Validate user exists
 	user, err := s.userRepo.FindByID(userID)
@@ -307,7 +396,7 @@ func (s *bookingService) CreateBooking(userID, hotelID, roomID primitive.ObjectI
 	}
 
 	// Validate hotel exists
-	_, err = s.hotelRepo.FindByID(hotelID)
+	hotel, err := s.hotelRepo.FindByID(hotelID)
 	if err != nil {
 		return nil, errors.New("hotel not found")
 	}
@@ -322,16 +411,23 @@ func (s *bookingService) CreateBooking(userID, hotelID, roomID primitive.ObjectI
 		return nil, errors.New("room does not belong to the specified hotel")
 	}
 
-	// Calculate point cost
-	pointCost, err := s.CalculatePointCost(roomID, startDate, endDate)
+	// Apply the booking limits of the user's tier
+	tier, err := s.checkTierLimits(user, hotel, startDate)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if user has enough points
-	if user.PointBalance < pointCost {
-		return nil, errors.New("insufficient point balance")
+	// Charge the quoted cost when a valid quote is presented, otherwise calculate it now
+	var quote *StayQuote
+	if quoteToken != "" {
+		quote, err = s.redeemQuote(quoteToken, userID, hotel, roomID, startDate, endDate)
+	} else {
+		quote, err = s.CalculatePointCostWithDetails(roomID, startDate, endDate)
 	}
+	if err != nil {
+		return nil, err
+	}
+	pointCost := quote.TotalPoints
 
 	// Create booking
 	booking := &models.Booking{
@@ -339,41 +435,82 @@ func (s *bookingService) CreateBooking(userID, hotelID, roomID primitive.ObjectI
 		UserID:    userID,
 		HotelID:   hotelID,
 		RoomID:    roomID,
-		CheckIn:   startDate,
-		CheckOut:  endDate,
+		CheckIn:   hotel.CheckInAt(startDate),
+		CheckOut:  hotel.CheckOutAt(endDate),
 		PointCost: pointCost,
+		Discounts: quote.Discounts,
+		Nights:    bookingNights(quote.Nights),
 		Status:    "confirmed",
 		CreatedAt: time.Now(),
 	}
 
-	// Save booking
-	if err := s.bookingRepo.Create(booking); err != nil {
-		return nil, err
+	if pool != nil {
+		// Split the cost across the members of the user's pool
+		userPool, shares, err := s.poolShares(user, pointCost, pool.Shares)
+		if err != nil {
+			return nil, err
+		}
+		booking.PoolID = &userPool.ID
+		booking.Shares = shares
+	} else if user.AvailablePoints() < pointCost {
+		// Check if user has enough points (points held by pending bookings are not available)
+		return nil, errors.New("insufficient point balance")
+	}
+
+	if s.approvalRequired {
+		booking.Status = "pending"
+		booking.HoldUntil = s.holdUntil(booking)
 	}
 
-	// Deduct points from user's balance
-	if err := s.userRepo.UpdatePointBalance(userID, -pointCost); err != nil {
-		// Rollback booking creation if point deduction fails
-		s.bookingRepo.UpdateStatus(booking.ID, "cancelled")
+	// Save booking, deduct (or hold) points and record the transactions as one unit of work
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		ledger := s.ledger.withContext(ctx)
+		bookingRepo := s.bookingRepo.WithContext(ctx)
+
+		// Re-check availability and the tier's active booking limit inside the transaction
+		if err := reserveRoom(bookingRepo, booking); err != nil {
+			return err
+		}
+		if err := checkActiveBookingLimit(bookingRepo, userID, tier); err != nil {
+			return err
+		}
+
+		if err := bookingRepo.Create(booking); err != nil {
+			return err
+		}
+
+		// Pending bookings only reserve points until they are confirmed
+		if booking.Status == "pending" {
+			return s.holdShares(ledger, booking, "insufficient point balance")
+		}
+
+		// Points are taken from the lots that expire first
+		return s.debitShares(ledger, booking, models.TransactionBookingDeduction, "insufficient point balance")
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Create point transaction record
-	transaction := &models.PointTransaction{
-		ID:        primitive.NewObjectID(),
-		UserID:    userID,
-		Amount:    -pointCost,
-		Type:      "booking_deduction",
-		Reference: booking.ID.Hex(),
-		CreatedAt: time.Now(),
+	return booking, nil
+}
+
+// reserveRoom checks inside a transaction that no other active booking overlaps booking.
+// Locking the room makes concurrent transactions for the same room conflict, so the retried
+// one sees the other booking.
+func reserveRoom(bookingRepo repositories.BookingRepository, booking *models.Booking) error {
+	if err := bookingRepo.LockRoom(booking.RoomID); err != nil {
+		return err
 	}
 
-	if err := s.userRepo.CreatePointTransaction(transaction); err != nil {
-		// Log error but continue, as the booking and point deduction were successful
-		// In a production system, this should be handled more robustly
+	available, err := bookingRepo.CheckRoomAvailability(booking.RoomID, booking.CheckIn, booking.CheckOut)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return errors.New("room is not available for the selected dates")
 	}
 
-	return booking, nil
+	return nil
 }
 
 func (s *bookingService) GetBookingByID(id primitive.ObjectID) (*models.Booking, error) {
@@ -408,37 +545,36 @@ func (s *bookingService) CancelBooking(id primitive.ObjectID, userID primitive.O
 		return errors.New("booking already completed")
 	}
 
-	// Check if booking is within 24 hours of check-in
+	// Check if booking is within 24 hours of check-in (saved at the hotel's local check-in time)
 	// This is a business rule that can be modified based on requirements
 	if time.Until(booking.CheckIn) < 24*time.Hour {
 		return errors.New("cannot cancel booking within 24 hours of check-in")
 	}
 
-	// Update booking status
-	if err := s.bookingRepo.UpdateStatus(id, "cancelled"); err != nil {
-		return err
-	}
+	// Update booking status and refund points as one unit of work
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		bookingRepo := s.bookingRepo.WithContext(ctx)
 
-	// Refund points to user
-	if err := s.userRepo.UpdatePointBalance(booking.UserID, booking.PointCost); err != nil {
-		return err
-	}
+		// Re-read the booking so a concurrent cancellation cannot refund twice
+		booking, err := bookingRepo.FindByID(id)
+		if err != nil {
+			return err
+		}
 
-	// Create refund transaction record
-	transaction := &models.PointTransaction{
-		ID:        primitive.NewObjectID(),
-		UserID:    booking.UserID,
-		Amount:    booking.PointCost,
-		Type:      "booking_refund",
-		Reference: booking.ID.Hex(),
-		CreatedAt: time.Now(),
-	}
+		if booking.Status == "cancelled" {
+			return errors.New("booking already cancelled")
+		}
 
-	if err := s.userRepo.CreatePointTransaction(transaction); err != nil {
-		// Log error but continue, as the cancellation and point refund were successful
-	}
+		if booking.Status == "completed" {
+			return errors.New("booking already completed")
+		}
 
-	return nil
+		if err := bookingRepo.UpdateStatus(id, "cancelled"); err != nil {
+			return err
+		}
+
+		return s.releaseBooking(s.ledger.withContext(ctx), booking, models.TransactionBookingRefund)
+	})
 }
 
 // Admin operations
@@ -471,104 +607,93 @@ func (s *bookingService) UpdateBookingStatus(id primitive.ObjectID, status strin
 		return errors.New("invalid booking status")
 	}
 
-	// Get current booking
-	booking, err := s.bookingRepo.FindByID(id)
-	if err != nil {
-		return err
-	}
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		bookingRepo := s.bookingRepo.WithContext(ctx)
+		ledger := s.ledger.withContext(ctx)
 
-	// Handle status change from cancelled to something else (need to re-deduct points)
-	if booking.Status == "cancelled" && status != "cancelled" {
-		// Check if user has enough points
-		user, err := s.userRepo.FindByID(booking.UserID)
+		// Get current booking
+		booking, err := bookingRepo.FindByID(id)
 		if err != nil {
 			return err
 		}
 
-		if user.PointBalance < booking.PointCost {
-			return errors.New("insufficient point balance to reactivate booking")
+		if booking.Status == status {
+			return nil
 		}
 
-		// Deduct points again
-		if err := s.userRepo.UpdatePointBalance(booking.UserID, -booking.PointCost); err != nil {
-			return err
+		// The room may have been booked by someone else since the cancellation
+		if booking.Status == "cancelled" {
+			if err := reserveRoom(bookingRepo, booking); err != nil {
+				return err
+			}
 		}
 
-		// Create point transaction record
-		transaction := &models.PointTransaction{
-			ID:        primitive.NewObjectID(),
-			UserID:    booking.UserID,
-			Amount:    -booking.PointCost,
-			Type:      "booking_reactivation",
-			Reference: booking.ID.Hex(),
-			CreatedAt: time.Now(),
-		}
+		switch {
+		case booking.Status == "cancelled" && status == "pending":
+			// Reactivate as a new hold awaiting approval
+			if err := s.holdShares(ledger, booking, "insufficient point balance to reactivate booking"); err != nil {
+				return err
+			}
 
-		if err := s.userRepo.CreatePointTransaction(transaction); err != nil {
-			// Log error but continue
-		}
-	}
+			if err := bookingRepo.UpdateHoldUntil(id, s.holdUntil(booking)); err != nil {
+				return err
+			}
 
-	// Handle status change to cancelled (need to refund points)
-	if booking.Status != "cancelled" && status == "cancelled" {
-		// Refund points
-		if err := s.userRepo.UpdatePointBalance(booking.UserID, booking.PointCost); err != nil {
-			return err
-		}
+		case booking.Status == "cancelled":
+			// Handle status change from cancelled to something else (need to re-deduct points)
+			if err := s.debitShares(ledger, booking, models.TransactionBookingReactivation, "insufficient point balance to reactivate booking"); err != nil {
+				return err
+			}
 
-		// Create refund transaction record
-		transaction := &models.PointTransaction{
-			ID:        primitive.NewObjectID(),
-			UserID:    booking.UserID,
-			Amount:    booking.PointCost,
-			Type:      "booking_refund",
-			Reference: booking.ID.Hex(),
-			CreatedAt: time.Now(),
-		}
+		case booking.Status == "pending" && status != "cancelled":
+			// Approval converts the hold into a deduction
+			if err := s.releaseShares(ledger, booking); err != nil {
+				return err
+			}
 
-		if err := s.userRepo.CreatePointTransaction(transaction); err != nil {
-			// Log error but continue
+			// Held points can still be lost to expiry while the booking awaits approval
+			if err := s.debitShares(ledger, booking, models.TransactionBookingDeduction, "insufficient point balance to confirm booking"); err != nil {
+				return err
+			}
+
+		case status == "pending":
+			return errors.New("confirmed booking cannot be moved back to pending")
+
+		case status == "cancelled":
+			// Handle status change to cancelled (refund points, or release the hold of a pending booking)
+			if err := s.releaseBooking(ledger, booking, models.TransactionBookingRefund); err != nil {
+				return err
+			}
 		}
-	}
 
-	// Update status
-	return s.bookingRepo.UpdateStatus(id, status)
+		// Update status
+		return bookingRepo.UpdateStatus(id, status)
+	})
 }
 
 func (s *bookingService) DeleteBooking(id primitive.ObjectID) error {
 	// This is a hard delete and should be used with caution
 	// In a production system, you might want to implement soft delete instead
 
-	// Get booking first to check if it exists
-	booking, err := s.bookingRepo.FindByID(id)
-	if err != nil {
-		return err
-	}
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		bookingRepo := s.bookingRepo.WithContext(ctx)
 
-	// If booking is confirmed, refund points before deleting
-	if booking.Status != "cancelled" {
-		// Refund points
-		if err := s.userRepo.UpdatePointBalance(booking.UserID, booking.PointCost); err != nil {
+		// Get booking first to check if it exists
+		booking, err := bookingRepo.FindByID(id)
+		if err != nil {
 			return err
 		}
 
-		// Create refund transaction record
-		transaction := &models.PointTransaction{
-			ID:        primitive.NewObjectID(),
-			UserID:    booking.UserID,
-			Amount:    booking.PointCost,
-			Type:      "booking_deletion_refund",
-			Reference: booking.ID.Hex(),
-			CreatedAt: time.Now(),
+		// If booking is still active, refund points (or release the hold) before deleting
+		if booking.Status != "cancelled" {
+			if err := s.releaseBooking(s.ledger.withContext(ctx), booking, models.TransactionBookingDeletionRefund); err != nil {
+				return err
+			}
 		}
 
-		if err := s.userRepo.CreatePointTransaction(transaction); err != nil {
-			// Log error but continue
-		}
-	}
-
-	// Delete booking
-	return s.bookingRepo.Delete(id)
+		// Delete booking
+		return bookingRepo.Delete(id)
+	})
 }
 
 // Analytics
@@ -581,6 +706,50 @@ func (s *bookingService) GetActiveBookingsByUser(userID primitive.ObjectID) ([]m
 	return s.bookingRepo.FindActiveByUserID(userID)
 }
 
+func (s *bookingService) ExpirePendingBookings(now time.Time) (int, error) {
+	bookings, err := s.bookingRepo.FindExpiredHolds(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, booking := range bookings {
+		released := false
+		err := s.txManager.WithTransaction(func(ctx context.Context) error {
+			bookingRepo := s.bookingRepo.WithContext(ctx)
+
+			// Re-read the booking, it may have been approved in the meantime
+			current, err := bookingRepo.FindByID(booking.ID)
+			if err != nil {
+				return err
+			}
+
+			if current.Status != "pending" || current.HoldUntil == nil || current.HoldUntil.After(now) {
+				released = false
+				return nil
+			}
+
+			if err := bookingRepo.UpdateStatus(current.ID, "cancelled"); err != nil {
+				return err
+			}
+
+			released = true
+			return s.releaseBooking(s.ledger.withContext(ctx), current, models.TransactionBookingRefund)
+		})
+		if err != nil {
+			// Continue with the next booking, this one is retried on the next run
+			log.Printf("Failed to expire pending booking %s: %v", booking.ID.Hex(), err)
+			continue
+		}
+
+		if released {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
 // Helper functions
 
 // isAdmin checks if a user has admin role
@@ -593,6 +762,225 @@ func (s *bookingService) isAdmin(userID primitive.ObjectID) (bool, error) {
 	return user.Role == models.RoleAdmin, nil
 }
 
+// checkTierLimits enforces the booking window of the user's tier and returns the tier, nil if none applies.
+// checkIn is a calendar date of the hotel, the window counts from today at the hotel.
+func (s *bookingService) checkTierLimits(user *models.User, hotel *models.Hotel, checkIn time.Time) (*models.Tier, error) {
+	tier, err := s.tierService.ResolveTier(user)
+	if err != nil {
+		return nil, err
+	}
+	if tier == nil {
+		return nil, nil
+	}
+
+	if tier.BookingWindowDays > 0 {
+		lastCheckIn := hotel.Today().AddDate(0, 0, tier.BookingWindowDays+1)
+		if !checkIn.Before(lastCheckIn) {
+			return nil, errors.New("check-in date is beyond your booking window")
+		}
+	}
+
+	return tier, nil
+}
+
+// checkActiveBookingLimit enforces the maximum active bookings of the user's tier inside a
+// transaction. Locking the user makes concurrent bookings by the same user conflict, so the
+// retried one counts the other booking.
+func checkActiveBookingLimit(bookingRepo repositories.BookingRepository, userID primitive.ObjectID, tier *models.Tier) error {
+	if tier == nil || tier.MaxActiveBookings <= 0 {
+		return nil
+	}
+
+	if err := bookingRepo.LockUser(userID); err != nil {
+		return err
+	}
+
+	active, err := bookingRepo.FindActiveByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(active) >= tier.MaxActiveBookings {
+		return errors.New("maximum active bookings reached")
+	}
+
+	return nil
+}
+
+// holdUntil returns the approval deadline for a pending booking, never later than check-in
+func (s *bookingService) holdUntil(booking *models.Booking) *time.Time {
+	holdUntil := time.Now().Add(s.holdDuration)
+	if holdUntil.After(booking.CheckIn) {
+		holdUntil = booking.CheckIn
+	}
+
+	return &holdUntil
+}
+
+// poolShares splits pointCost across the members of the user's pool. Requested shares
+// are validated as given, otherwise the user pays first and the other members cover the rest.
+func (s *bookingService) poolShares(user *models.User, pointCost int, requested []models.BookingShare) (*models.Pool, []models.BookingShare, error) {
+	pool, err := s.poolService.FindUserPool(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pool == nil {
+		return nil, nil, errors.New("user does not belong to a pool")
+	}
+
+	if len(requested) > 0 {
+		total := 0
+		seen := make(map[primitive.ObjectID]bool, len(requested))
+		for _, share := range requested {
+			if !pool.HasMember(share.UserID) {
+				return nil, nil, errors.New("pool share user is not a pool member")
+			}
+			if share.PointCost <= 0 || seen[share.UserID] {
+				return nil, nil, errors.New("invalid pool shares")
+			}
+			// Point anggota lain hanya boleh dipakai sampai batas yang ia setujui
+			if share.UserID != user.ID && share.PointCost > pool.ShareLimit(share.UserID) {
+				return nil, nil, errors.New("pool share exceeds the member's share limit")
+			}
+			seen[share.UserID] = true
+			total += share.PointCost
+
+			member, err := s.userRepo.FindByID(share.UserID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if member.AvailablePoints() < share.PointCost {
+				return nil, nil, errors.New("insufficient point balance")
+			}
+		}
+
+		if total != pointCost {
+			return nil, nil, errors.New("pool shares must add up to the point cost")
+		}
+
+		return pool, requested, nil
+	}
+
+	payers := []primitive.ObjectID{user.ID}
+	for _, memberID := range pool.MemberIDs {
+		if memberID != user.ID {
+			payers = append(payers, memberID)
+		}
+	}
+
+	remaining := pointCost
+	var shares []models.BookingShare
+	for _, payerID := range payers {
+		if remaining == 0 {
+			break
+		}
+
+		member, err := s.userRepo.FindByID(payerID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		available := member.AvailablePoints()
+		if payerID != user.ID {
+			available = min(available, pool.ShareLimit(payerID))
+		}
+
+		if take := min(available, remaining); take > 0 {
+			shares = append(shares, models.BookingShare{UserID: payerID, PointCost: take})
+			remaining -= take
+		}
+	}
+
+	if remaining > 0 {
+		return nil, nil, errors.New("insufficient point balance")
+	}
+
+	return pool, shares, nil
+}
+
+// holdShares reserves each payer's share of a pending booking, re-reading balances
+// so a concurrent change cannot overdraw anyone
+func (s *bookingService) holdShares(ledger *pointLedger, booking *models.Booking, insufficientMessage string) error {
+	for _, share := range booking.PointShares() {
+		user, err := ledger.userRepo.FindByID(share.UserID)
+		if err != nil {
+			return err
+		}
+
+		if user.AvailablePoints() < share.PointCost {
+			return errors.New(insufficientMessage)
+		}
+
+		if err := ledger.userRepo.UpdateHeldPoints(share.UserID, share.PointCost); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseShares releases the points each payer has reserved for a pending booking
+func (s *bookingService) releaseShares(ledger *pointLedger, booking *models.Booking) error {
+	for _, share := range booking.PointShares() {
+		if err := ledger.userRepo.UpdateHeldPoints(share.UserID, -share.PointCost); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// debitShares deducts each payer's share of a booking, recording one transaction per payer
+func (s *bookingService) debitShares(ledger *pointLedger, booking *models.Booking, transactionType, insufficientMessage string) error {
+	for _, share := range booking.PointShares() {
+		user, err := ledger.userRepo.FindByID(share.UserID)
+		if err != nil {
+			return err
+		}
+
+		if user.AvailablePoints() < share.PointCost {
+			return errors.New(insufficientMessage)
+		}
+
+		if _, err := ledger.debit(share.UserID, share.PointCost, transactionType, booking.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseBooking gives back the points of a booking that is no longer active:
+// a pending booking releases its hold, any other booking is refunded
+func (s *bookingService) releaseBooking(ledger *pointLedger, booking *models.Booking, transactionType string) error {
+	if booking.Status == "pending" {
+		return s.releaseShares(ledger, booking)
+	}
+
+	return s.refundBooking(ledger, booking, transactionType)
+}
+
+// refundBooking returns each payer's share of a booking to the lots their deduction was taken from,
+// so a pool booking is refunded in the same proportion it was paid
+func (s *bookingService) refundBooking(ledger *pointLedger, booking *models.Booking, transactionType string) error {
+	for _, share := range booking.PointShares() {
+		deduction, err := ledger.userRepo.FindLatestPointTransaction(
+			share.UserID,
+			[]string{models.TransactionBookingDeduction, models.TransactionBookingReactivation},
+			booking.ID.Hex(),
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := ledger.restore(deduction, share.UserID, share.PointCost, transactionType, booking.ID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // enrichBookingWithDetails adds hotel and room details to booking
 func (s *bookingService) enrichBookingWithDetails(booking *models.Booking) error {
 	// Get hotel details
@@ -695,144 +1083,36 @@ func isSameDay(t1, t2 time.Time) bool {
 
 // Additional helper functions if needed:
 
-// calculateRefundAmount calculates refund amount based on cancellation policy
-// This is a placeholder for more complex refund policies
-func (s *bookingService) calculateRefundAmount(booking *models.Booking) int {
-	// Basic implementation: Full refund if cancelled more than 24 hours before check-in
-	if time.Until(booking.CheckIn) >= 24*time.Hour {
-		return booking.PointCost
-	}
-
-	// No refund if cancelled within 24 hours
-	return 0
-}
-
-// isRoomAvailableOnDate checks if a specific room is available on a specific date
-func (s *bookingService) isRoomAvailableOnDate(roomID primitive.ObjectID, date time.Time) (bool, error) {
-	// Format the date to have consistent comparison (only date, no time)
-	checkDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-
-	// Check if there are any bookings for this room that overlap with this date
-	bookings, err := s.bookingRepo.FindActiveByRoomIDAndDateRange(
-		roomID,
-		checkDate,
-		checkDate.AddDate(0, 0, 1), // Next day
-	)
-
-	if err != nil {
-		return false, err
-	}
-
-	// Room is available if there are no bookings for this date
-	if len(bookings) > 0 {
-		return false, nil
-	}
-
-	// Also check for availability rules
-	avail, err := s.hotelRepo.FindRoomAvailabilityByDate(roomID, checkDate)
-	if err != nil {
-		return false, err
-	}
-
-	// If there's a specific availability rule and it's set to not available
-	if avail != nil && !avail.Available {
-		return false, nil
-	}
-
-	// Room is available if we reach here
-	return true, nil
-}
-
-// getHotelOccupancyRate calculates hotel occupancy rate for a given period
-func (s *bookingService) getHotelOccupancyRate(hotelID primitive.ObjectID, startDate, endDate time.Time) (float64, error) {
-	// Get all rooms in the hotel
-	rooms, err := s.hotelRepo.FindRoomsByHotelID(hotelID)
-	if err != nil {
-		return 0, err
-	}
-
-	// Calculate total room-nights available
-	// Each room can be booked for each night in the period
-	days := int(endDate.Sub(startDate).Hours()/24) + 1
-	totalRoomNights := len(rooms) * days
-
-	if totalRoomNights == 0 {
-		return 0, errors.New("no rooms available in the hotel")
-	}
-
-	// Get all bookings for this hotel during the period
-	bookings, err := s.bookingRepo.FindByHotelID(hotelID)
-	if err != nil {
-		return 0, err
-	}
-
-	// Count booked room-nights
-	bookedRoomNights := 0
-	for _, booking := range bookings {
-		// Skip cancelled bookings
-		if booking.Status == "cancelled" {
-			continue
-		}
-
-		// Check if booking overlaps with the period
-		if !(booking.CheckOut.Before(startDate) || booking.CheckIn.After(endDate)) {
-			// Calculate overlap period
-			overlapStart := booking.CheckIn
-			if overlapStart.Before(startDate) {
-				overlapStart = startDate
-			}
-
-			overlapEnd := booking.CheckOut
-			if overlapEnd.After(endDate) {
-				overlapEnd = endDate
-			}
-
-			// Calculate number of days in overlap
-			overlapDays := int(overlapEnd.Sub(overlapStart).Hours()/24) + 1
-			bookedRoomNights += overlapDays
-		}
-	}
-
-	// Calculate occupancy rate
-	return float64(bookedRoomNights) / float64(totalRoomNights), nil
-}
-
 // getUserPointActivity gets user's point activity for a given period
 func (s *bookingService) getUserPointActivity(userID primitive.ObjectID, startDate, endDate time.Time) ([]models.PointTransaction, error) {
-	// This function would require additional repository methods to filter transactions by date
-	// For now, we'll return a placeholder error
-	return nil, errors.New("method not implemented")
-
-	// Ideal implementation would be:
-	// return s.userRepo.GetPointTransactionsByDateRange(userID, startDate, endDate)
-}
-
-// validateBookingPeriod validates if a booking period is valid
-func (s *bookingService) validateBookingPeriod(checkIn, checkOut time.Time) error {
-	// Standardize dates
-	startDate := time.Date(checkIn.Year(), checkIn.Month(), checkIn.Day(), 0, 0, 0, 0, checkIn.Location())
-	endDate := time.Date(checkOut.Year(), checkOut.Month(), checkOut.Day(), 0, 0, 0, 0, checkOut.Location())
-
-	// Check if check-in date is before check-out date
-	if !startDate.Before(endDate) {
-		return errors.New("check-in date must be before check-out date")
+	entries, _, err := s.userRepo.FindPointHistory(userID, repositories.PointHistoryFilter{
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, 0, 0)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if check-in date is not in the past
-	today := time.Now()
-	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
-
-	if startDate.Before(today) {
-		return errors.New("check-in date cannot be in the past")
+	transactions := make([]models.PointTransaction, len(entries))
+	for i, entry := range entries {
+		transactions[i] = entry.PointTransaction
 	}
 
-	// Check if booking period is not too long (e.g., max 14 days)
-	maxDuration := 14 * 24 * time.Hour
-	duration := endDate.Sub(startDate)
+	return transactions, nil
+}
 
-	if duration > maxDuration {
-		return errors.New("booking duration cannot exceed 14 days")
+// bookingNights mengubah rincian biaya per malam menjadi data yang disimpan pada booking
+func bookingNights(details []DailyPointDetail) []models.BookingNight {
+	nights := make([]models.BookingNight, 0, len(details))
+	for _, detail := range details {
+		nights = append(nights, models.BookingNight{
+			Date:           detail.Date,
+			DayType:        detail.DayType,
+			Name:           detail.Name,
+			PointCost:      detail.PointCost,
+			DiscountPoints: detail.DiscountPoints,
+			RuleID:         detail.RuleID,
+		})
 	}
-
-	return nil
+	return nights
 }
@@ -1,31 +1,86 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"hotel-point-app/internal/models"
 	"hotel-point-app/internal/repositories"
+	"hotel-point-app/pkg/ical"
 )
 
+// maxBulkDates adalah jumlah tanggal terbanyak dalam satu impor atau perubahan massal
+const maxBulkDates = 366
+
+// Tindakan perubahan massal DateRule untuk setiap tanggal
+const (
+	SpecialDateCreate    = "create"    // Belum ada DateRule, dibuat
+	SpecialDateUpdate    = "update"    // DateRule yang ada diubah
+	SpecialDateUnchanged = "unchanged" // DateRule yang ada sudah sama
+	SpecialDateDelete    = "delete"    // DateRule yang ada dihapus
+	SpecialDateNotFound  = "not_found" // Tidak ada DateRule untuk dihapus
+)
+
+// SpecialDateItem adalah hasil perubahan massal untuk satu tanggal
+type SpecialDateItem struct {
+	Date      time.Time        `json:"date"`
+	Name      string           `json:"name,omitempty"`
+	Action    string           `json:"action"`
+	PointCost int              `json:"point_cost,omitempty"`
+	Current   *models.DateRule `json:"current,omitempty"` // DateRule sebelum perubahan
+}
+
+// SpecialDateResult adalah ringkasan impor kalender atau perubahan massal DateRule.
+// Applied false berarti hanya preview.
+type SpecialDateResult struct {
+	Applied   bool              `json:"applied"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Deleted   int               `json:"deleted"`
+	NotFound  int               `json:"not_found"`
+	Items     []SpecialDateItem `json:"items"`
+}
+
 type DateService interface {
 	GetDateRules(startDate, endDate time.Time) ([]models.DateRule, error)
-	GetPointCostForDate(date time.Time) (int, error)
+	GetRecurringRules() ([]models.RecurringDateRule, error)
+	// GetDateRuleHistory mengembalikan semua versi DateRule, versi terlama lebih dulu
+	GetDateRuleHistory(id primitive.ObjectID) ([]models.DateRuleVersion, error)
 
 	// Admin functions
-	SetSpecialDate(rule *models.DateRule) error
-	DeleteSpecialDate(id primitive.ObjectID) error
+	// Setiap perubahan DateRule dicatat sebagai DateRuleVersion baru atas nama changedBy
+	SetSpecialDate(rule *models.DateRule, changedBy primitive.ObjectID) error
+	DeleteSpecialDate(id primitive.ObjectID, changedBy primitive.ObjectID) error
+	// BulkSetSpecialDates membuat atau mengubah DateRule setiap tanggal dengan tipe, nama dan biaya
+	// yang sama dalam satu transaksi
+	BulkSetSpecialDates(dates []time.Time, ruleType, name string, pointCost int, changedBy primitive.ObjectID) (*SpecialDateResult, error)
+	// BulkDeleteSpecialDates menghapus DateRule setiap tanggal dalam satu transaksi
+	BulkDeleteSpecialDates(dates []time.Time, changedBy primitive.ObjectID) (*SpecialDateResult, error)
+	CreateRecurringRule(rule *models.RecurringDateRule) error
+	UpdateRecurringRule(rule *models.RecurringDateRule) error
+	DeleteRecurringRule(id primitive.ObjectID) error
+	// ImportCalendar membuat atau mengubah DateRule untuk setiap tanggal event dengan tipe dan biaya
+	// yang dipilih. Dengan dryRun, tidak ada yang disimpan dan hasilnya hanya preview.
+	ImportCalendar(events []ical.Event, ruleType string, pointCost int, dryRun bool, changedBy primitive.ObjectID) (*SpecialDateResult, error)
 }
 
 type dateService struct {
-	dateRepo repositories.DateRepository
+	dateRepo        repositories.DateRepository
+	txManager       repositories.TransactionManager
+	settingsService SettingsService
 }
 
-func NewDateService(dateRepo repositories.DateRepository) DateService {
+func NewDateService(dateRepo repositories.DateRepository, txManager repositories.TransactionManager, settingsService SettingsService) DateService {
 	return &dateService{
-		dateRepo: dateRepo,
+		dateRepo:        dateRepo,
+		txManager:       txManager,
+		settingsService: settingsService,
 	}
 }
 
@@ -33,44 +88,492 @@ func (s *dateService) GetDateRules(startDate, endDate time.Time) ([]models.DateR
 	return s.dateRepo.FindDateRules(startDate, endDate)
 }
 
-func (s *dateService) GetPointCostForDate(date time.Time) (int, error) {
-	return s.dateRepo.GetPointCostForDate(date)
+func (s *dateService) GetRecurringRules() ([]models.RecurringDateRule, error) {
+	return s.dateRepo.FindRecurringRules()
+}
+
+func (s *dateService) GetDateRuleHistory(id primitive.ObjectID) ([]models.DateRuleVersion, error) {
+	versions, err := s.dateRepo.FindDateRuleVersions(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rule yang dihapus tidak lagi ada di date_rules, tetapi riwayatnya tetap bisa dilihat
+	if len(versions) == 0 {
+		if _, err := s.dateRepo.FindDateRuleByID(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
 }
 
 // Implementasi fungsi admin
 
-func (s *dateService) SetSpecialDate(rule *models.DateRule) error {
+func (s *dateService) SetSpecialDate(rule *models.DateRule, changedBy primitive.ObjectID) error {
 	// Validasi data rule
-	if rule.Date.IsZero() || rule.Type == "" || rule.PointCost <= 0 || rule.PointCost > 3 {
+	if rule.Date.IsZero() || rule.Type == "" || rule.PointCost <= 0 {
 		return errors.New("invalid date rule data")
 	}
+	if err := s.checkMaxPointCost(rule.PointCost); err != nil {
+		return err
+	}
 
 	// Format tanggal agar hanya menyimpan komponen tanggal (tanpa waktu)
-	rule.Date = time.Date(rule.Date.Year(), rule.Date.Month(), rule.Date.Day(), 0, 0, 0, 0, rule.Date.Location())
+	rule.Date = models.CalendarDate(rule.Date)
 
 	// Generate ID baru jika kosong
 	if rule.ID.IsZero() {
 		rule.ID = primitive.NewObjectID()
 	}
 
-	// Cek apakah sudah ada rule untuk tanggal ini
-	start := rule.Date
-	end := time.Date(start.Year(), start.Month(), start.Day(), 23, 59, 59, 999999999, start.Location())
-	existingRules, err := s.dateRepo.FindDateRules(start, end)
+	// Rule dan versinya disimpan bersama
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		dateRepo := s.dateRepo.WithContext(ctx)
+
+		// Cek apakah sudah ada rule untuk tanggal ini
+		existingRules, err := dateRepo.FindDateRules(rule.Date, rule.Date)
+		if err != nil {
+			return err
+		}
+
+		if len(existingRules) > 0 {
+			// Update rule yang ada
+			return saveDateRule(dateRepo, rule, &existingRules[0], changedBy)
+		}
+
+		// Buat rule baru
+		return saveDateRule(dateRepo, rule, nil, changedBy)
+	})
+}
+
+func (s *dateService) DeleteSpecialDate(id primitive.ObjectID, changedBy primitive.ObjectID) error {
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		dateRepo := s.dateRepo.WithContext(ctx)
+
+		rule, err := dateRepo.FindDateRuleByID(id)
+		if err != nil {
+			return err
+		}
+
+		return deleteDateRule(dateRepo, rule, changedBy)
+	})
+}
+
+func (s *dateService) BulkSetSpecialDates(dates []time.Time, ruleType, name string, pointCost int, changedBy primitive.ObjectID) (*SpecialDateResult, error) {
+	dates, err := normalizeBulkDates(dates)
 	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(dates))
+	for _, date := range dates {
+		names[date.Format("2006-01-02")] = strings.TrimSpace(name)
+	}
+
+	return s.upsertDateRules(dates, names, ruleType, pointCost, false, changedBy)
+}
+
+func (s *dateService) BulkDeleteSpecialDates(dates []time.Time, changedBy primitive.ObjectID) (*SpecialDateResult, error) {
+	dates, err := normalizeBulkDates(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *SpecialDateResult
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		dateRepo := s.dateRepo.WithContext(ctx)
+
+		existing, err := findDateRulesByDay(dateRepo, dates)
+		if err != nil {
+			return err
+		}
+
+		// fn bisa diulang oleh transaksi, hasil selalu dihitung ulang
+		result = &SpecialDateResult{Applied: true}
+		for _, date := range dates {
+			item := SpecialDateItem{Date: date, Action: SpecialDateNotFound}
+
+			current, exists := existing[date.Format("2006-01-02")]
+			if !exists {
+				result.NotFound++
+				result.Items = append(result.Items, item)
+				continue
+			}
+
+			currentRule := current
+			item.Current = &currentRule
+			item.Name = current.Name
+			item.Action = SpecialDateDelete
+			if err := deleteDateRule(dateRepo, &current, changedBy); err != nil {
+				return err
+			}
+
+			result.Deleted++
+			result.Items = append(result.Items, item)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// saveDateRule membuat rule baru (existing nil) atau mengubah existing, lalu mencatat versinya
+func saveDateRule(dateRepo repositories.DateRepository, rule *models.DateRule, existing *models.DateRule, changedBy primitive.ObjectID) error {
+	now := time.Now()
+	rule.UpdatedBy = changedBy
+	rule.UpdatedAt = now
+
+	if existing == nil {
+		rule.Version = 1
+		if err := dateRepo.CreateDateRule(rule); err != nil {
+			return err
+		}
+		return recordDateRuleVersion(dateRepo, rule, models.DateRuleCreated, changedBy, now)
+	}
+
+	version, err := recordBaselineVersion(dateRepo, existing)
+	if err != nil {
+		return err
+	}
+
+	rule.ID = existing.ID
+	rule.Version = version + 1
+	if err := dateRepo.UpdateDateRule(rule); err != nil {
+		return err
+	}
+	return recordDateRuleVersion(dateRepo, rule, models.DateRuleUpdated, changedBy, now)
+}
+
+// deleteDateRule menghapus rule dan mencatat versi deleted sehingga riwayatnya tetap ada
+func deleteDateRule(dateRepo repositories.DateRepository, rule *models.DateRule, changedBy primitive.ObjectID) error {
+	version, err := recordBaselineVersion(dateRepo, rule)
+	if err != nil {
+		return err
+	}
+
+	if err := dateRepo.DeleteDateRule(rule.ID); err != nil {
 		return err
 	}
 
-	if len(existingRules) > 0 {
-		// Update rule yang ada
-		rule.ID = existingRules[0].ID
-		return s.dateRepo.UpdateDateRule(rule)
+	deleted := *rule
+	deleted.Version = version + 1
+	return recordDateRuleVersion(dateRepo, &deleted, models.DateRuleDeleted, changedBy, time.Now())
+}
+
+// recordBaselineVersion mencatat isi rule yang dibuat sebelum versioning (Version 0) sebagai versi 1
+// yang berlaku sejak rule dibuat, sehingga harga as_of sebelum perubahan pertamanya tetap memakai
+// rule ini. Mengembalikan nomor versi rule saat ini.
+func recordBaselineVersion(dateRepo repositories.DateRepository, rule *models.DateRule) (int, error) {
+	if rule.Version > 0 {
+		return rule.Version, nil
+	}
+
+	// Waktu pembuatan rule lama hanya diketahui dari ObjectID-nya
+	baseline := *rule
+	baseline.Version = 1
+	if err := recordDateRuleVersion(dateRepo, &baseline, models.DateRuleCreated, primitive.NilObjectID, rule.ID.Timestamp()); err != nil {
+		return 0, err
 	}
 
-	// Buat rule baru
-	return s.dateRepo.CreateDateRule(rule)
+	return baseline.Version, nil
+}
+
+// recordDateRuleVersion menyimpan isi rule sebagai versi rule.Version
+func recordDateRuleVersion(dateRepo repositories.DateRepository, rule *models.DateRule, action string, changedBy primitive.ObjectID, changedAt time.Time) error {
+	return dateRepo.CreateDateRuleVersion(&models.DateRuleVersion{
+		ID:        primitive.NewObjectID(),
+		RuleID:    rule.ID,
+		Version:   rule.Version,
+		Action:    action,
+		Date:      rule.Date,
+		Type:      rule.Type,
+		PointCost: rule.PointCost,
+		Name:      rule.Name,
+		ChangedBy: changedBy,
+		ChangedAt: changedAt,
+	})
 }
 
-func (s *dateService) DeleteSpecialDate(id primitive.ObjectID) error {
-	return s.dateRepo.DeleteDateRule(id)
+func (s *dateService) CreateRecurringRule(rule *models.RecurringDateRule) error {
+	if err := validateRecurringRule(rule); err != nil {
+		return err
+	}
+	if err := s.checkMaxPointCost(rule.PointCost); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if rule.ID.IsZero() {
+		rule.ID = primitive.NewObjectID()
+	}
+
+	return s.dateRepo.CreateRecurringRule(rule)
+}
+
+func (s *dateService) UpdateRecurringRule(rule *models.RecurringDateRule) error {
+	existing, err := s.dateRepo.FindRecurringRuleByID(rule.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRecurringRule(rule); err != nil {
+		return err
+	}
+	if err := s.checkMaxPointCost(rule.PointCost); err != nil {
+		return err
+	}
+
+	rule.CreatedAt = existing.CreatedAt
+	rule.UpdatedAt = time.Now()
+
+	return s.dateRepo.UpdateRecurringRule(rule)
+}
+
+func (s *dateService) DeleteRecurringRule(id primitive.ObjectID) error {
+	return s.dateRepo.DeleteRecurringRule(id)
+}
+
+func (s *dateService) ImportCalendar(events []ical.Event, ruleType string, pointCost int, dryRun bool, changedBy primitive.ObjectID) (*SpecialDateResult, error) {
+	// Event pada tanggal yang sama digabung menjadi satu DateRule
+	names := make(map[string][]string)
+	var dates []time.Time
+	for _, event := range events {
+		// Event yang sangat panjang ditolak sebelum diuraikan per tanggal
+		if event.End.Sub(event.Start) > maxBulkDates*24*time.Hour {
+			return nil, errors.New("calendar covers more than 366 dates")
+		}
+		for _, date := range event.Dates() {
+			key := date.Format("2006-01-02")
+			if _, exists := names[key]; !exists {
+				// Berhenti segera setelah batas terlewati, bukan setelah semua event diuraikan
+				if len(dates) == maxBulkDates {
+					return nil, errors.New("calendar covers more than 366 dates")
+				}
+				dates = append(dates, date)
+			}
+			if event.Summary != "" {
+				names[key] = append(names[key], event.Summary)
+			} else if names[key] == nil {
+				names[key] = []string{}
+			}
+		}
+	}
+	if len(dates) == 0 {
+		return nil, errors.New("calendar contains no events")
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	joinedNames := make(map[string]string, len(names))
+	for key, summaries := range names {
+		joinedNames[key] = strings.Join(summaries, " / ")
+	}
+
+	return s.upsertDateRules(dates, joinedNames, ruleType, pointCost, dryRun, changedBy)
+}
+
+// upsertDateRules membuat atau mengubah DateRule setiap tanggal (sudah urut, tanpa duplikat)
+// dengan nama dari names. Semua perubahan disimpan dalam satu transaksi, dryRun hanya membaca.
+func (s *dateService) upsertDateRules(dates []time.Time, names map[string]string, ruleType string, pointCost int, dryRun bool, changedBy primitive.ObjectID) (*SpecialDateResult, error) {
+	if ruleType != "regular" && ruleType != "weekend" && ruleType != "holiday" {
+		return nil, errors.New("invalid type, must be: regular, weekend, or holiday")
+	}
+	if pointCost <= 0 {
+		return nil, errors.New("point cost must be at least 1")
+	}
+	if err := s.checkMaxPointCost(pointCost); err != nil {
+		return nil, err
+	}
+
+	var result *SpecialDateResult
+	upsert := func(dateRepo repositories.DateRepository) error {
+		existing, err := findDateRulesByDay(dateRepo, dates)
+		if err != nil {
+			return err
+		}
+
+		// fn bisa diulang oleh transaksi, hasil selalu dihitung ulang
+		result = &SpecialDateResult{Applied: !dryRun}
+		for _, date := range dates {
+			key := date.Format("2006-01-02")
+			item := SpecialDateItem{
+				Date:      date,
+				Name:      names[key],
+				Action:    SpecialDateCreate,
+				PointCost: pointCost,
+			}
+
+			rule := &models.DateRule{
+				ID:        primitive.NewObjectID(),
+				Date:      date,
+				Type:      ruleType,
+				PointCost: pointCost,
+				Name:      item.Name,
+			}
+
+			if current, exists := existing[key]; exists {
+				currentRule := current
+				item.Current = &currentRule
+				item.Action = SpecialDateUpdate
+				if current.Type == rule.Type && current.PointCost == rule.PointCost && current.Name == rule.Name {
+					item.Action = SpecialDateUnchanged
+				}
+			}
+
+			switch item.Action {
+			case SpecialDateCreate:
+				result.Created++
+				if !dryRun {
+					err = saveDateRule(dateRepo, rule, nil, changedBy)
+				}
+			case SpecialDateUpdate:
+				result.Updated++
+				if !dryRun {
+					err = saveDateRule(dateRepo, rule, item.Current, changedBy)
+				}
+			default:
+				result.Unchanged++
+			}
+			if err != nil {
+				return err
+			}
+
+			result.Items = append(result.Items, item)
+		}
+
+		return nil
+	}
+
+	var err error
+	if dryRun {
+		err = upsert(s.dateRepo)
+	} else {
+		err = s.txManager.WithTransaction(func(ctx context.Context) error {
+			return upsert(s.dateRepo.WithContext(ctx))
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// findDateRulesByDay memuat DateRule dari tanggal pertama sampai terakhir dengan kunci YYYY-MM-DD
+func findDateRulesByDay(dateRepo repositories.DateRepository, dates []time.Time) (map[string]models.DateRule, error) {
+	rules, err := dateRepo.FindDateRules(dates[0], dates[len(dates)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]models.DateRule, len(rules))
+	for _, rule := range rules {
+		existing[rule.Date.Format("2006-01-02")] = rule
+	}
+
+	return existing, nil
+}
+
+// normalizeBulkDates membuang komponen waktu dan duplikat lalu mengurutkan tanggal
+func normalizeBulkDates(dates []time.Time) ([]time.Time, error) {
+	seen := make(map[string]bool, len(dates))
+	var normalized []time.Time
+	for _, date := range dates {
+		date = models.CalendarDate(date)
+		key := date.Format("2006-01-02")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, date)
+	}
+
+	if len(normalized) == 0 {
+		return nil, errors.New("no dates selected")
+	}
+	if len(normalized) > maxBulkDates {
+		return nil, errors.New("cannot change more than 366 dates at once")
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i].Before(normalized[j]) })
+
+	return normalized, nil
+}
+
+// checkMaxPointCost menolak biaya aturan tanggal di atas batas pada pengaturan biaya point
+func (s *dateService) checkMaxPointCost(pointCost int) error {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return err
+	}
+
+	if pointCost > settings.Pricing.MaxPointCost {
+		return errors.New("point cost exceeds the maximum point cost")
+	}
+
+	return nil
+}
+
+// validateRecurringRule memeriksa field aturan berulang sesuai pola pengulangannya
+func validateRecurringRule(rule *models.RecurringDateRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	if rule.Name == "" {
+		return errors.New("rule name cannot be empty")
+	}
+
+	if rule.Type != "regular" && rule.Type != "weekend" && rule.Type != "holiday" {
+		return errors.New("invalid type, must be: regular, weekend, or holiday")
+	}
+
+	if rule.PointCost <= 0 {
+		return errors.New("point cost must be at least 1")
+	}
+
+	switch rule.Recurrence {
+	case models.RecurrenceYearly:
+		if !validMonthDay(rule.Month, rule.Day) {
+			return errors.New("invalid month or day")
+		}
+		rule.Weekday, rule.Week, rule.EndMonth, rule.EndDay = 0, 0, 0, 0
+	case models.RecurrenceNthWeekday:
+		if rule.Month < 0 || rule.Month > 12 {
+			return errors.New("invalid month or day")
+		}
+		if rule.Weekday < time.Sunday || rule.Weekday > time.Saturday {
+			return errors.New("weekday must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if rule.Week != -1 && (rule.Week < 1 || rule.Week > 5) {
+			return errors.New("week must be between 1 and 5, or -1 for the last week")
+		}
+		rule.Day, rule.EndMonth, rule.EndDay = 0, 0, 0
+	case models.RecurrenceRange:
+		if !validMonthDay(rule.Month, rule.Day) || !validMonthDay(rule.EndMonth, rule.EndDay) {
+			return errors.New("invalid month or day")
+		}
+		rule.Weekday, rule.Week = 0, 0
+	default:
+		return errors.New("invalid recurrence, must be: yearly, nth_weekday, or range")
+	}
+
+	if rule.ValidFrom != nil && rule.ValidUntil != nil && rule.ValidFrom.After(*rule.ValidUntil) {
+		return errors.New("valid_from cannot be after valid_until")
+	}
+
+	return nil
+}
+
+// validMonthDay memeriksa tanggal bulan-hari tanpa tahun, 29 Februari diperbolehkan
+func validMonthDay(month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+
+	// 2024 adalah tahun kabisat sehingga 29 Februari dianggap valid
+	return day <= time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
 }
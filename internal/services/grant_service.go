@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// Periode pemberian point
+const (
+	GrantPeriodYearly    = "yearly"
+	GrantPeriodQuarterly = "quarterly"
+	GrantPeriodMonthly   = "monthly"
+)
+
+// Pembulatan grant pro-rata
+const (
+	ProrateRoundingFloor   = "floor"
+	ProrateRoundingCeil    = "ceil"
+	ProrateRoundingNearest = "nearest"
+)
+
+// GrantPeriod adalah satu periode pemberian point, mis. "2026", "2026-Q2" atau "2026-05"
+type GrantPeriod struct {
+	Key   string
+	Start time.Time
+	End   time.Time // Eksklusif
+}
+
+// GrantRunResult adalah ringkasan satu kali proses pemberian point
+type GrantRunResult struct {
+	Period  string `json:"period"`
+	Granted int    `json:"granted"`
+	Skipped int    `json:"skipped"`
+	Failed  int    `json:"failed"`
+}
+
+type GrantService interface {
+	CurrentPeriod(now time.Time) GrantPeriod
+	ParsePeriod(key string) (GrantPeriod, error)
+	RunGrants(period GrantPeriod) (*GrantRunResult, error)
+	// GrantUser memberikan point periode ke satu user, false jika sudah pernah diberikan
+	// atau user belum mulai bekerja. User yang mulai bekerja di tengah periode mendapat grant pro-rata.
+	GrantUser(userID primitive.ObjectID, period GrantPeriod) (bool, error)
+	// CreateUserWithGrant menyimpan user baru dan memberikan point periode dalam satu transaksi
+	CreateUserWithGrant(user *models.User, period GrantPeriod) error
+	// SetUserJoinedAt mengubah tanggal mulai bekerja user, lalu memberikan grant periode berjalan
+	// atau kekurangannya jika jatah menurut tanggal baru lebih besar dari yang sudah diterima
+	SetUserJoinedAt(userID primitive.ObjectID, joinedAt time.Time) error
+}
+
+type grantService struct {
+	userRepo     repositories.UserRepository
+	txManager    repositories.TransactionManager
+	tierService  TierService
+	ledger       *pointLedger
+	annualPoints int // Jatah tahunan untuk user yang tidak masuk tier mana pun
+	period       string
+	expiryYears  int
+	rounding     string // Pembulatan grant pro-rata
+}
+
+func NewGrantService(
+	userRepo repositories.UserRepository,
+	lotRepo repositories.PointLotRepository,
+	txManager repositories.TransactionManager,
+	tierService TierService,
+	annualPoints int,
+	period string,
+	expiryYears int,
+	rounding string,
+) GrantService {
+	if period != GrantPeriodQuarterly && period != GrantPeriodMonthly {
+		period = GrantPeriodYearly
+	}
+	if rounding != ProrateRoundingCeil && rounding != ProrateRoundingNearest {
+		rounding = ProrateRoundingFloor
+	}
+
+	return &grantService{
+		userRepo:     userRepo,
+		txManager:    txManager,
+		tierService:  tierService,
+		ledger:       newPointLedger(userRepo, lotRepo),
+		annualPoints: annualPoints,
+		period:       period,
+		expiryYears:  expiryYears,
+		rounding:     rounding,
+	}
+}
+
+func (s *grantService) CurrentPeriod(now time.Time) GrantPeriod {
+	switch s.period {
+	case GrantPeriodMonthly:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return GrantPeriod{Key: start.Format("2006-01"), Start: start, End: start.AddDate(0, 1, 0)}
+	case GrantPeriodQuarterly:
+		quarter := (int(now.Month())-1)/3 + 1
+		start := time.Date(now.Year(), time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, now.Location())
+		return GrantPeriod{Key: fmt.Sprintf("%d-Q%d", now.Year(), quarter), Start: start, End: start.AddDate(0, 3, 0)}
+	default:
+		start := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		return GrantPeriod{Key: start.Format("2006"), Start: start, End: start.AddDate(1, 0, 0)}
+	}
+}
+
+func (s *grantService) ParsePeriod(key string) (GrantPeriod, error) {
+	var start time.Time
+	var err error
+
+	switch s.period {
+	case GrantPeriodMonthly:
+		start, err = time.ParseInLocation("2006-01", key, time.Local)
+	case GrantPeriodQuarterly:
+		var year, quarter int
+		if _, scanErr := fmt.Sscanf(key, "%d-Q%d", &year, &quarter); scanErr != nil || quarter < 1 || quarter > 4 {
+			err = errors.New("invalid quarter")
+		} else {
+			start = time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, time.Local)
+		}
+	default:
+		start, err = time.ParseInLocation("2006", key, time.Local)
+	}
+
+	if err != nil {
+		return GrantPeriod{}, fmt.Errorf("invalid %s grant period: %s", s.period, key)
+	}
+
+	period := s.CurrentPeriod(start)
+	if period.Key != key {
+		return GrantPeriod{}, fmt.Errorf("invalid %s grant period: %s", s.period, key)
+	}
+
+	return period, nil
+}
+
+func (s *grantService) RunGrants(period GrantPeriod) (*GrantRunResult, error) {
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GrantRunResult{Period: period.Key}
+	for _, user := range users {
+		granted, err := s.GrantUser(user.ID, period)
+		if err != nil {
+			// Lanjutkan ke user berikutnya, user ini akan dicoba lagi pada run berikutnya
+			log.Printf("Failed to grant points to user %s for period %s: %v", user.ID.Hex(), period.Key, err)
+			result.Failed++
+			continue
+		}
+
+		if granted {
+			result.Granted++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	return result, nil
+}
+
+func (s *grantService) GrantUser(userID primitive.ObjectID, period GrantPeriod) (bool, error) {
+	granted := false
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		var err error
+		granted, err = s.grantUser(s.ledger.withContext(ctx), userID, period, false)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return granted, nil
+}
+
+func (s *grantService) CreateUserWithGrant(user *models.User, period GrantPeriod) error {
+	// User tidak tersimpan jika grant gagal
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		ledger := s.ledger.withContext(ctx)
+		if err := ledger.userRepo.Create(user); err != nil {
+			return err
+		}
+
+		_, err := s.grantUser(ledger, user.ID, period, false)
+		return err
+	})
+}
+
+func (s *grantService) SetUserJoinedAt(userID primitive.ObjectID, joinedAt time.Time) error {
+	return s.txManager.WithTransaction(func(ctx context.Context) error {
+		ledger := s.ledger.withContext(ctx)
+		if _, err := ledger.userRepo.FindByID(userID); err != nil {
+			return err
+		}
+
+		if err := ledger.userRepo.UpdateJoinedAt(userID, joinedAt); err != nil {
+			return err
+		}
+
+		// Tanggal mulai bekerja baru dapat mengaktifkan atau menambah grant periode berjalan
+		_, err := s.grantUser(ledger, userID, s.CurrentPeriod(time.Now()), true)
+		return err
+	})
+}
+
+// grantTransactionTypes adalah transaksi yang dihitung sebagai grant satu periode
+var grantTransactionTypes = []string{models.TransactionAnnualGrant, models.TransactionProratedGrant, models.TransactionGrantTopUp}
+
+// grantUser memberikan point periode ke user memakai ledger transaksi yang sedang berjalan.
+// Dengan topUp, user yang sudah menerima grant periode ini mendapat kekurangannya jika jatahnya bertambah.
+func (s *grantService) grantUser(ledger *pointLedger, userID primitive.ObjectID, period GrantPeriod, topUp bool) (bool, error) {
+	// Penanda grant membuat scheduler, cmd/grant dan perubahan tanggal mulai bekerja yang berjalan
+	// bersamaan untuk user dan periode yang sama saling bentrok, sehingga yang diulang melihat grant sebelumnya
+	if err := ledger.userRepo.LockPointGrant(userID, period.Key); err != nil {
+		return false, err
+	}
+
+	// Grant bersifat idempoten per user per periode
+	granted, err := ledger.userRepo.SumPointTransactionsByReference(userID, grantTransactionTypes, period.Key)
+	if err != nil {
+		return false, err
+	}
+	if granted > 0 && !topUp {
+		return false, nil
+	}
+
+	user, err := ledger.userRepo.FindByID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	// User yang belum mulai bekerja baru mendapat grant setelah tanggal mulai bekerjanya tiba
+	if user.JoinedAt != nil && (!user.JoinedAt.Before(period.End) || user.JoinedAt.After(time.Now())) {
+		return false, nil
+	}
+
+	// Besar grant mengikuti tier user
+	annualPoints := s.annualPoints
+	tier, err := s.tierService.ResolveTier(user)
+	if err != nil {
+		return false, err
+	}
+	if tier != nil {
+		annualPoints = tier.AnnualPoints
+	}
+
+	points := s.pointsPerPeriod(annualPoints)
+	transactionType := models.TransactionAnnualGrant
+	if user.JoinedAt != nil && user.JoinedAt.After(period.Start) {
+		points = s.prorate(points, period, *user.JoinedAt)
+		transactionType = models.TransactionProratedGrant
+	}
+
+	// Grant yang sudah diterima tidak ditarik kembali, hanya kekurangannya yang ditambahkan
+	if granted > 0 {
+		points -= granted
+		transactionType = models.TransactionGrantTopUp
+	}
+
+	if points <= 0 {
+		return false, nil
+	}
+
+	// Point periode ini kedaluwarsa bersama dalam satu lot
+	if _, err := ledger.grant(userID, points, transactionType, period.Key, lotExpiry(period.Start, s.expiryYears)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// pointsPerPeriod membagi jatah tahunan sesuai panjang periode
+func (s *grantService) pointsPerPeriod(annualPoints int) int {
+	switch s.period {
+	case GrantPeriodMonthly:
+		return annualPoints / 12
+	case GrantPeriodQuarterly:
+		return annualPoints / 4
+	default:
+		return annualPoints
+	}
+}
+
+// prorate menghitung bagian points untuk sisa bulan periode sejak joinedAt, bulan joinedAt ikut dihitung
+func (s *grantService) prorate(points int, period GrantPeriod, joinedAt time.Time) int {
+	periodMonths := monthsBetween(period.Start, period.End)
+	remainingMonths := monthsBetween(joinedAt, period.End)
+	if remainingMonths >= periodMonths {
+		return points
+	}
+
+	share := float64(points) * float64(remainingMonths) / float64(periodMonths)
+	switch s.rounding {
+	case ProrateRoundingCeil:
+		return int(math.Ceil(share))
+	case ProrateRoundingNearest:
+		return int(math.Round(share))
+	default:
+		return int(math.Floor(share))
+	}
+}
+
+// monthsBetween menghitung selisih bulan kalender dari start sampai end
+func monthsBetween(start, end time.Time) int {
+	return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+}
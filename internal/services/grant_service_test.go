@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// fakeUserRepository menyimpan user dan transaksi point di memori.
+// Method yang tidak dipakai grant akan panic lewat interface yang di-embed.
+type fakeUserRepository struct {
+	repositories.UserRepository
+	users        map[primitive.ObjectID]*models.User
+	transactions []models.PointTransaction
+}
+
+func newFakeUserRepository() *fakeUserRepository {
+	return &fakeUserRepository{users: make(map[primitive.ObjectID]*models.User)}
+}
+
+func (r *fakeUserRepository) WithContext(ctx context.Context) repositories.UserRepository {
+	return r
+}
+
+func (r *fakeUserRepository) Create(user *models.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func (r *fakeUserRepository) FindByID(id primitive.ObjectID) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *fakeUserRepository) UpdateJoinedAt(userID primitive.ObjectID, joinedAt time.Time) error {
+	r.users[userID].JoinedAt = &joinedAt
+	return nil
+}
+
+func (r *fakeUserRepository) UpdatePointBalance(userID primitive.ObjectID, points int) error {
+	r.users[userID].PointBalance += points
+	return nil
+}
+
+func (r *fakeUserRepository) CreatePointTransaction(transaction *models.PointTransaction) error {
+	r.transactions = append(r.transactions, *transaction)
+	return nil
+}
+
+func (r *fakeUserRepository) SumPointTransactionsByReference(userID primitive.ObjectID, transactionTypes []string, reference string) (int, error) {
+	total := 0
+	for _, transaction := range r.transactions {
+		for _, transactionType := range transactionTypes {
+			if transaction.UserID == userID && transaction.Type == transactionType && transaction.Reference == reference {
+				total += transaction.Amount
+			}
+		}
+	}
+	return total, nil
+}
+
+func (r *fakeUserRepository) LockPointGrant(userID primitive.ObjectID, reference string) error {
+	return nil
+}
+
+type fakePointLotRepository struct {
+	repositories.PointLotRepository
+	lots []models.PointLot
+}
+
+func (r *fakePointLotRepository) WithContext(ctx context.Context) repositories.PointLotRepository {
+	return r
+}
+
+func (r *fakePointLotRepository) Create(lot *models.PointLot) error {
+	lot.ID = primitive.NewObjectID()
+	r.lots = append(r.lots, *lot)
+	return nil
+}
+
+type fakeTransactionManager struct{}
+
+func (fakeTransactionManager) WithTransaction(fn func(ctx context.Context) error) error {
+	return fn(context.Background())
+}
+
+// fakeTierService tidak memiliki tier, sehingga semua user mendapat jatah default
+type fakeTierService struct {
+	TierService
+}
+
+func (fakeTierService) ResolveTier(user *models.User) (*models.Tier, error) {
+	return nil, nil
+}
+
+func newTestGrantService(userRepo *fakeUserRepository) GrantService {
+	return NewGrantService(userRepo, &fakePointLotRepository{}, fakeTransactionManager{}, fakeTierService{}, 120, GrantPeriodYearly, 1, ProrateRoundingFloor)
+}
+
+func TestRegisterBeforeJoinedAtGetsFullGrant(t *testing.T) {
+	userRepo := newFakeUserRepository()
+	service := newTestGrantService(userRepo)
+	period := service.CurrentPeriod(time.Now())
+
+	user := &models.User{ID: primitive.NewObjectID(), Name: "New Hire"}
+	if err := service.CreateUserWithGrant(user, period); err != nil {
+		t.Fatalf("CreateUserWithGrant: %v", err)
+	}
+
+	// Tanpa tanggal mulai bekerja, user mendapat jatah penuh seperti grant tahunan biasa
+	if len(userRepo.transactions) != 1 || userRepo.transactions[0].Type != models.TransactionAnnualGrant || userRepo.transactions[0].Amount != 120 {
+		t.Fatalf("expected one annual grant of 120, got %+v", userRepo.transactions)
+	}
+
+	// Tanggal mulai bekerja di tengah periode tidak menarik kembali grant yang sudah diterima
+	joinedAt := period.Start.AddDate(0, 6, 0)
+	if err := service.SetUserJoinedAt(user.ID, joinedAt); err != nil {
+		t.Fatalf("SetUserJoinedAt: %v", err)
+	}
+
+	stored, _ := userRepo.FindByID(user.ID)
+	if stored.JoinedAt == nil || !stored.JoinedAt.Equal(joinedAt) {
+		t.Fatalf("expected joined_at %v, got %v", joinedAt, stored.JoinedAt)
+	}
+	if len(userRepo.transactions) != 1 || stored.PointBalance != 120 {
+		t.Fatalf("expected no additional grant, got balance %d and %+v", stored.PointBalance, userRepo.transactions)
+	}
+}
+
+func TestSetUserJoinedAtActivatesAndTopsUpGrant(t *testing.T) {
+	userRepo := newFakeUserRepository()
+	service := newTestGrantService(userRepo)
+	now := time.Now()
+	period := service.CurrentPeriod(now)
+
+	// User terdaftar sebelum tanggal mulai bekerjanya tiba belum mendapat grant
+	future := now.AddDate(0, 0, 7)
+	user := &models.User{ID: primitive.NewObjectID(), Name: "Future Hire", JoinedAt: &future}
+	if err := service.CreateUserWithGrant(user, period); err != nil {
+		t.Fatalf("CreateUserWithGrant: %v", err)
+	}
+	if len(userRepo.transactions) != 0 {
+		t.Fatalf("expected no grant before the joined date, got %+v", userRepo.transactions)
+	}
+
+	// Admin mengoreksi tanggal mulai bekerja ke awal bulan ini: grant pro-rata diberikan
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	if err := service.SetUserJoinedAt(user.ID, monthStart); err != nil {
+		t.Fatalf("SetUserJoinedAt: %v", err)
+	}
+
+	prorated := 120 * (13 - int(now.Month())) / 12
+	stored, _ := userRepo.FindByID(user.ID)
+	if stored.PointBalance != prorated {
+		t.Fatalf("expected balance %d after activation, got %d", prorated, stored.PointBalance)
+	}
+
+	// Mengoreksi lagi ke awal periode hanya menambahkan kekurangannya
+	if err := service.SetUserJoinedAt(user.ID, period.Start); err != nil {
+		t.Fatalf("SetUserJoinedAt: %v", err)
+	}
+
+	stored, _ = userRepo.FindByID(user.ID)
+	if stored.PointBalance != 120 {
+		t.Fatalf("expected balance 120 after correction, got %d", stored.PointBalance)
+	}
+	if prorated < 120 {
+		last := userRepo.transactions[len(userRepo.transactions)-1]
+		if last.Type != models.TransactionGrantTopUp || last.Amount != 120-prorated {
+			t.Fatalf("expected top-up of %d, got %+v", 120-prorated, last)
+		}
+	}
+
+	// Menyimpan tanggal yang sama lagi tidak memberikan grant tambahan
+	count := len(userRepo.transactions)
+	if err := service.SetUserJoinedAt(user.ID, period.Start); err != nil {
+		t.Fatalf("SetUserJoinedAt: %v", err)
+	}
+	if len(userRepo.transactions) != count {
+		t.Fatalf("expected no further grant, got %+v", userRepo.transactions)
+	}
+}
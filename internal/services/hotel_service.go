@@ -20,6 +20,8 @@ type HotelService interface {
 	CreateHotel(hotel *models.Hotel) error
 	UpdateHotel(hotel *models.Hotel) error
 	DeleteHotel(id primitive.ObjectID) error
+	// SetLastMinuteDiscount mengatur potongan last-minute hotel, nil untuk menonaktifkan
+	SetLastMinuteDiscount(hotelID primitive.ObjectID, rule *models.LastMinuteDiscount) (*models.Hotel, error)
 	CreateRoom(room *models.Room) error
 	UpdateRoom(room *models.Room) error
 	DeleteRoom(id primitive.ObjectID) error
@@ -61,6 +63,20 @@ func (s *hotelService) CreateHotel(hotel *models.Hotel) error {
 		return errors.New("required hotel fields cannot be empty")
 	}
 
+	// Hotel baru selalu menyimpan zona waktu dan jam check-in/check-out secara eksplisit
+	if hotel.TimeZone == "" {
+		hotel.TimeZone = models.DefaultHotelTimeZone
+	}
+	if hotel.CheckInTime == "" {
+		hotel.CheckInTime = models.DefaultCheckInTime
+	}
+	if hotel.CheckOutTime == "" {
+		hotel.CheckOutTime = models.DefaultCheckOutTime
+	}
+	if err := validateHotelSchedule(hotel); err != nil {
+		return err
+	}
+
 	// Set waktu pembuatan dan update
 	now := time.Now()
 	hotel.CreatedAt = now
@@ -80,6 +96,10 @@ func (s *hotelService) UpdateHotel(hotel *models.Hotel) error {
 		return errors.New("hotel ID cannot be empty")
 	}
 
+	if err := validateHotelSchedule(hotel); err != nil {
+		return err
+	}
+
 	// Memastikan hotel ada
 	_, err := s.hotelRepo.FindByID(hotel.ID)
 	if err != nil {
@@ -92,6 +112,27 @@ func (s *hotelService) UpdateHotel(hotel *models.Hotel) error {
 	return s.hotelRepo.Update(hotel)
 }
 
+// validateHotelSchedule memeriksa zona waktu dan jam check-in/check-out hotel yang diisi
+func validateHotelSchedule(hotel *models.Hotel) error {
+	if hotel.TimeZone != "" {
+		if _, err := models.LoadTimeZone(hotel.TimeZone); err != nil {
+			return errors.New("invalid time zone")
+		}
+	}
+	if hotel.CheckInTime != "" {
+		if _, _, err := models.ParseClock(hotel.CheckInTime); err != nil {
+			return errors.New("invalid check-in time, use HH:MM")
+		}
+	}
+	if hotel.CheckOutTime != "" {
+		if _, _, err := models.ParseClock(hotel.CheckOutTime); err != nil {
+			return errors.New("invalid check-out time, use HH:MM")
+		}
+	}
+
+	return nil
+}
+
 func (s *hotelService) DeleteHotel(id primitive.ObjectID) error {
 	// Memastikan hotel ada
 	_, err := s.hotelRepo.FindByID(id)
@@ -112,6 +153,34 @@ func (s *hotelService) DeleteHotel(id primitive.ObjectID) error {
 	return s.hotelRepo.Delete(id)
 }
 
+func (s *hotelService) SetLastMinuteDiscount(hotelID primitive.ObjectID, rule *models.LastMinuteDiscount) (*models.Hotel, error) {
+	if rule != nil {
+		if rule.WithinDays < 1 {
+			return nil, errors.New("within_days must be at least 1")
+		}
+		if rule.OccupancyBelow < 1 || rule.OccupancyBelow > 100 {
+			return nil, errors.New("occupancy_below must be between 1 and 100")
+		}
+		if rule.PercentOff < 1 || rule.PercentOff > 100 {
+			return nil, errors.New("percent_off must be between 1 and 100")
+		}
+	}
+
+	hotel, err := s.hotelRepo.FindByID(hotelID)
+	if err != nil {
+		return nil, err
+	}
+
+	hotel.LastMinuteDiscount = rule
+	hotel.UpdatedAt = time.Now()
+
+	if err := s.hotelRepo.Update(hotel); err != nil {
+		return nil, err
+	}
+
+	return hotel, nil
+}
+
 func (s *hotelService) CreateRoom(room *models.Room) error {
 	// Validasi data kamar
 	if room.HotelID.IsZero() || room.Name == "" || room.Description == "" || room.Capacity <= 0 {
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/repositories"
+)
+
+// BookingTimeChange adalah perubahan waktu check-in/check-out satu booking
+type BookingTimeChange struct {
+	BookingID   primitive.ObjectID `json:"booking_id"`
+	HotelID     primitive.ObjectID `json:"hotel_id"`
+	OldCheckIn  time.Time          `json:"old_check_in"`
+	OldCheckOut time.Time          `json:"old_check_out"`
+	CheckIn     time.Time          `json:"check_in"`
+	CheckOut    time.Time          `json:"check_out"`
+}
+
+// BookingTimeReport adalah ringkasan normalisasi waktu booking. Applied false berarti hanya preview.
+type BookingTimeReport struct {
+	Applied         bool                `json:"applied"`
+	BookingsChecked int                 `json:"bookings_checked"`
+	Changed         int                 `json:"changed"`
+	Changes         []BookingTimeChange `json:"changes"`
+}
+
+type MigrationService interface {
+	// NormalizeBookingTimes memindahkan check-in dan check-out setiap booking ke jam check-in dan
+	// check-out hotel di zona waktu hotel pada tanggal lokal yang sama. Booking yang dibuat sebelum
+	// hotel memiliki zona waktu menyimpan 14:00/12:00 UTC dan bentrok dengan booking baru di hari
+	// check-out. Aman dijalankan berulang; dryRun hanya melaporkan perubahan.
+	NormalizeBookingTimes(dryRun bool) (*BookingTimeReport, error)
+}
+
+type migrationService struct {
+	bookingRepo repositories.BookingRepository
+	hotelRepo   repositories.HotelRepository
+}
+
+func NewMigrationService(bookingRepo repositories.BookingRepository, hotelRepo repositories.HotelRepository) MigrationService {
+	return &migrationService{
+		bookingRepo: bookingRepo,
+		hotelRepo:   hotelRepo,
+	}
+}
+
+func (s *migrationService) NormalizeBookingTimes(dryRun bool) (*BookingTimeReport, error) {
+	hotels, err := s.hotelRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &BookingTimeReport{Applied: !dryRun}
+	for i := range hotels {
+		hotel := &hotels[i]
+
+		bookings, err := s.bookingRepo.FindByHotelID(hotel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, booking := range bookings {
+			report.BookingsChecked++
+
+			checkIn := hotel.CheckInAt(hotel.LocalDate(booking.CheckIn))
+			checkOut := hotel.CheckOutAt(hotel.LocalDate(booking.CheckOut))
+			if checkIn.Equal(booking.CheckIn) && checkOut.Equal(booking.CheckOut) {
+				continue
+			}
+
+			if !dryRun {
+				if err := s.bookingRepo.UpdateCheckTimes(booking.ID, checkIn, checkOut); err != nil {
+					return nil, err
+				}
+			}
+
+			report.Changed++
+			report.Changes = append(report.Changes, BookingTimeChange{
+				BookingID:   booking.ID,
+				HotelID:     hotel.ID,
+				OldCheckIn:  booking.CheckIn,
+				OldCheckOut: booking.CheckOut,
+				CheckIn:     checkIn,
+				CheckOut:    checkOut,
+			})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// pointLedger mencatat setiap perubahan point: saldo user, transaksi, dan lot.
+// Gunakan withContext(ctx) di dalam TransactionManager agar ketiganya selalu sesuai.
+type pointLedger struct {
+	userRepo repositories.UserRepository
+	lotRepo  repositories.PointLotRepository
+}
+
+func newPointLedger(userRepo repositories.UserRepository, lotRepo repositories.PointLotRepository) *pointLedger {
+	return &pointLedger{
+		userRepo: userRepo,
+		lotRepo:  lotRepo,
+	}
+}
+
+func (l *pointLedger) withContext(ctx context.Context) *pointLedger {
+	return newPointLedger(l.userRepo.WithContext(ctx), l.lotRepo.WithContext(ctx))
+}
+
+// credit menambah point tanpa tanggal kedaluwarsa
+func (l *pointLedger) credit(userID primitive.ObjectID, amount int, transactionType, reference string) (*models.PointTransaction, error) {
+	return l.record(userID, amount, transactionType, reference, nil)
+}
+
+// grant menambah point dalam lot baru yang kedaluwarsa pada expiresAt
+func (l *pointLedger) grant(userID primitive.ObjectID, amount int, transactionType, reference string, expiresAt time.Time) (*models.PointTransaction, error) {
+	lot := &models.PointLot{
+		UserID:    userID,
+		Source:    transactionType,
+		Reference: reference,
+		Amount:    amount,
+		Remaining: amount,
+		ExpiresAt: expiresAt,
+	}
+	if err := l.lotRepo.Create(lot); err != nil {
+		return nil, err
+	}
+
+	return l.record(userID, amount, transactionType, reference, []models.LotAllocation{{LotID: lot.ID, Amount: amount}})
+}
+
+// debit mengurangi point, mengambil dari lot yang paling cepat kedaluwarsa lebih dulu.
+// Bagian yang tidak tertutup lot diambil dari saldo tanpa kedaluwarsa.
+func (l *pointLedger) debit(userID primitive.ObjectID, amount int, transactionType, reference string) (*models.PointTransaction, error) {
+	allocations, err := l.consume(userID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.record(userID, -amount, transactionType, reference, allocations)
+}
+
+// transfer memindahkan point dari satu user ke user lain. Point yang diterima
+// mengikuti tanggal kedaluwarsa lot pengirim, sehingga transfer tidak memperpanjang masa berlaku.
+func (l *pointLedger) transfer(fromID, toID primitive.ObjectID, amount int, reference, note string) (*models.PointTransaction, *models.PointTransaction, error) {
+	outLots, err := l.consume(fromID, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &models.PointTransaction{
+		UserID:         fromID,
+		Amount:         -amount,
+		Type:           models.TransactionTransferOut,
+		Reference:      reference,
+		CounterpartyID: &toID,
+		Note:           note,
+		Lots:           outLots,
+	}
+	if err := l.apply(out); err != nil {
+		return nil, nil, err
+	}
+
+	// Satu lot penerima untuk setiap tanggal kedaluwarsa lot pengirim
+	var expiries []time.Time
+	amountByExpiry := make(map[time.Time]int)
+	for _, allocation := range outLots {
+		source, err := l.lotRepo.FindByID(allocation.LotID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := amountByExpiry[source.ExpiresAt]; !ok {
+			expiries = append(expiries, source.ExpiresAt)
+		}
+		amountByExpiry[source.ExpiresAt] -= allocation.Amount
+	}
+
+	inLots := make([]models.LotAllocation, 0, len(expiries))
+	for _, expiresAt := range expiries {
+		lot := &models.PointLot{
+			UserID:    toID,
+			Source:    models.TransactionTransferIn,
+			Reference: reference,
+			Amount:    amountByExpiry[expiresAt],
+			Remaining: amountByExpiry[expiresAt],
+			ExpiresAt: expiresAt,
+		}
+		if err := l.lotRepo.Create(lot); err != nil {
+			return nil, nil, err
+		}
+
+		inLots = append(inLots, models.LotAllocation{LotID: lot.ID, Amount: lot.Amount})
+	}
+
+	in := &models.PointTransaction{
+		UserID:         toID,
+		Amount:         amount,
+		Type:           models.TransactionTransferIn,
+		Reference:      reference,
+		CounterpartyID: &fromID,
+		Note:           note,
+		Lots:           inLots,
+	}
+	if err := l.apply(in); err != nil {
+		return nil, nil, err
+	}
+
+	return out, in, nil
+}
+
+// adjust mengubah saldo sebesar amount atas nama admin. Penambahan tidak kedaluwarsa,
+// pengurangan mengambil dari lot seperti debit.
+func (l *pointLedger) adjust(userID primitive.ObjectID, amount int, reasonCode, comment string, actorID primitive.ObjectID) (*models.PointTransaction, error) {
+	var allocations []models.LotAllocation
+	if amount < 0 {
+		var err error
+		allocations, err = l.consume(userID, -amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transaction := &models.PointTransaction{
+		UserID:    userID,
+		Amount:    amount,
+		Type:      models.TransactionAdminAdjustment,
+		Reference: reasonCode,
+		Lots:      allocations,
+		ActorID:   &actorID,
+		Note:      comment,
+	}
+	if err := l.apply(transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// consume mengambil amount point dari lot user, dimulai dari yang paling cepat kedaluwarsa
+func (l *pointLedger) consume(userID primitive.ObjectID, amount int) ([]models.LotAllocation, error) {
+	lots, err := l.lotRepo.FindAvailableByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := amount
+	var allocations []models.LotAllocation
+	for _, lot := range lots {
+		if remaining == 0 {
+			break
+		}
+
+		take := min(lot.Remaining, remaining)
+		if err := l.lotRepo.Consume(lot.ID, take); err != nil {
+			return nil, err
+		}
+
+		allocations = append(allocations, models.LotAllocation{LotID: lot.ID, Amount: -take})
+		remaining -= take
+	}
+
+	return allocations, nil
+}
+
+// restore mengembalikan amount point dari transaksi debit original ke lot asalnya,
+// dimulai dari lot yang paling lama masa berlakunya. Tanpa original, point dikreditkan biasa.
+func (l *pointLedger) restore(original *models.PointTransaction, userID primitive.ObjectID, amount int, transactionType, reference string) (*models.PointTransaction, error) {
+	if original == nil {
+		return l.credit(userID, amount, transactionType, reference)
+	}
+
+	remaining := amount
+	var allocations []models.LotAllocation
+	for i := len(original.Lots) - 1; i >= 0 && remaining > 0; i-- {
+		give := min(-original.Lots[i].Amount, remaining)
+		if give <= 0 {
+			continue
+		}
+
+		if err := l.lotRepo.Restore(original.Lots[i].LotID, give); err != nil {
+			return nil, err
+		}
+
+		allocations = append(allocations, models.LotAllocation{LotID: original.Lots[i].LotID, Amount: give})
+		remaining -= give
+	}
+
+	return l.record(userID, amount, transactionType, reference, allocations)
+}
+
+// expire menghanguskan sisa point pada lot
+func (l *pointLedger) expire(lot *models.PointLot) (*models.PointTransaction, error) {
+	if err := l.lotRepo.MarkExpired(lot.ID); err != nil {
+		return nil, err
+	}
+
+	allocations := []models.LotAllocation{{LotID: lot.ID, Amount: -lot.Remaining}}
+	return l.record(lot.UserID, -lot.Remaining, models.TransactionPointExpiry, lot.ID.Hex(), allocations)
+}
+
+// record mengubah saldo user dan mencatat transaksinya di ledger
+func (l *pointLedger) record(userID primitive.ObjectID, amount int, transactionType, reference string, lots []models.LotAllocation) (*models.PointTransaction, error) {
+	transaction := &models.PointTransaction{
+		UserID:    userID,
+		Amount:    amount,
+		Type:      transactionType,
+		Reference: reference,
+		Lots:      lots,
+	}
+
+	if err := l.apply(transaction); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// apply mengubah saldo user sebesar transaction.Amount dan menyimpan transaksinya
+func (l *pointLedger) apply(transaction *models.PointTransaction) error {
+	if err := l.userRepo.UpdatePointBalance(transaction.UserID, transaction.Amount); err != nil {
+		return err
+	}
+
+	transaction.ID = primitive.NewObjectID()
+	transaction.CreatedAt = time.Now()
+	return l.userRepo.CreatePointTransaction(transaction)
+}
+
+// lotExpiry menghitung akhir masa berlaku point yang diberikan pada grantedAt,
+// yaitu akhir tahun grantedAt ditambah expiryYears tahun (1 = akhir tahun berikutnya)
+func lotExpiry(grantedAt time.Time, expiryYears int) time.Time {
+	return time.Date(grantedAt.Year()+expiryYears, time.December, 31, 23, 59, 59, 0, grantedAt.Location())
+}
@@ -1,24 +1,109 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"hotel-point-app/internal/models"
 	"hotel-point-app/internal/repositories"
+	"hotel-point-app/pkg/utils"
+)
+
+// PointExpiryBucket adalah jumlah point yang kedaluwarsa pada tanggal yang sama
+type PointExpiryBucket struct {
+	ExpiresAt *time.Time `json:"expires_at"` // nil untuk point tanpa tanggal kedaluwarsa
+	Points    int        `json:"points"`
+}
+
+// PointBalanceBreakdown adalah saldo point beserta rinciannya per tanggal kedaluwarsa
+type PointBalanceBreakdown struct {
+	PointBalance    int                 `json:"point_balance"`
+	HeldPoints      int                 `json:"held_points"`      // Ditahan booking pending
+	AvailablePoints int                 `json:"available_points"` // Saldo dikurangi point yang ditahan
+	Breakdown       []PointExpiryBucket `json:"breakdown"`
+}
+
+// TransferRequest adalah permintaan transfer point ke user lain
+type TransferRequest struct {
+	RecipientEmail string
+	Amount         int
+	Note           string
+}
+
+// PointTransfer adalah hasil transfer point
+type PointTransfer struct {
+	TransferID     string                   `json:"transfer_id"`
+	RecipientName  string                   `json:"recipient_name"`
+	RecipientEmail string                   `json:"recipient_email"`
+	Amount         int                      `json:"amount"`
+	Note           string                   `json:"note,omitempty"`
+	PointBalance   int                      `json:"point_balance"` // Saldo pengirim setelah transfer
+	Transaction    *models.PointTransaction `json:"transaction"`
+}
+
+// Kode alasan adjustment point oleh admin
+const (
+	AdjustmentReasonCorrection   = "correction"
+	AdjustmentReasonCompensation = "compensation"
+	AdjustmentReasonGoodwill     = "goodwill"
+	AdjustmentReasonPolicy       = "policy"
+	AdjustmentReasonOther        = "other"
 )
 
+// AdjustmentRequest adalah permintaan adjustment point oleh admin
+type AdjustmentRequest struct {
+	Amount     int // Positif menambah, negatif mengurangi
+	ReasonCode string
+	Comment    string
+	// AllowNegative mengizinkan point yang tersedia menjadi negatif setelah adjustment
+	AllowNegative bool
+}
+
+// PointAdjustment adalah hasil adjustment point
+type PointAdjustment struct {
+	PointBalance    int                      `json:"point_balance"`
+	AvailablePoints int                      `json:"available_points"` // Saldo dikurangi point yang ditahan booking pending
+	Transaction     *models.PointTransaction `json:"transaction"`
+}
+
 type PointService interface {
 	GetPointBalance(userID primitive.ObjectID) (int, error)
+	GetPointBreakdown(userID primitive.ObjectID) (*PointBalanceBreakdown, error)
 	GetPointHistory(userID primitive.ObjectID) ([]models.PointTransaction, error)
+	// FindPointHistory mengembalikan satu halaman riwayat point beserta total transaksi yang cocok
+	FindPointHistory(userID primitive.ObjectID, filter repositories.PointHistoryFilter, params utils.PaginationParams) ([]models.PointHistoryEntry, int, error)
+	// ExpirePoints menghanguskan sisa lot yang sudah lewat masa berlakunya, mengembalikan jumlah lot
+	ExpirePoints(now time.Time) (int, error)
+	TransferPoints(senderID primitive.ObjectID, req TransferRequest) (*PointTransfer, error)
+	AdjustPoints(userID, adminID primitive.ObjectID, req AdjustmentRequest) (*PointAdjustment, error)
 }
 
 type pointService struct {
-	userRepo repositories.UserRepository
+	userRepo        repositories.UserRepository
+	lotRepo         repositories.PointLotRepository
+	txManager       repositories.TransactionManager
+	settingsService SettingsService
+	ledger          *pointLedger
 }
 
-func NewPointService(userRepo repositories.UserRepository) PointService {
+func NewPointService(
+	userRepo repositories.UserRepository,
+	lotRepo repositories.PointLotRepository,
+	txManager repositories.TransactionManager,
+	settingsService SettingsService,
+) PointService {
 	return &pointService{
-		userRepo: userRepo,
+		userRepo:        userRepo,
+		lotRepo:         lotRepo,
+		txManager:       txManager,
+		settingsService: settingsService,
+		ledger:          newPointLedger(userRepo, lotRepo),
 	}
 }
 
@@ -31,6 +116,209 @@ func (s *pointService) GetPointBalance(userID primitive.ObjectID) (int, error) {
 	return user.PointBalance, nil
 }
 
+func (s *pointService) GetPointBreakdown(userID primitive.ObjectID) (*PointBalanceBreakdown, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	lots, err := s.lotRepo.FindAvailableByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Kelompokkan lot per tanggal kedaluwarsa
+	byExpiry := make(map[time.Time]int)
+	allocated := 0
+	for _, lot := range lots {
+		byExpiry[lot.ExpiresAt] += lot.Remaining
+		allocated += lot.Remaining
+	}
+
+	breakdown := make([]PointExpiryBucket, 0, len(byExpiry)+1)
+	for expiresAt, points := range byExpiry {
+		expiresAt := expiresAt
+		breakdown = append(breakdown, PointExpiryBucket{ExpiresAt: &expiresAt, Points: points})
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		return breakdown[i].ExpiresAt.Before(*breakdown[j].ExpiresAt)
+	})
+
+	// Saldo di luar lot (mis. saldo lama sebelum ada lot) tidak kedaluwarsa
+	if unallocated := user.PointBalance - allocated; unallocated != 0 {
+		breakdown = append(breakdown, PointExpiryBucket{Points: unallocated})
+	}
+
+	return &PointBalanceBreakdown{
+		PointBalance:    user.PointBalance,
+		HeldPoints:      user.HeldPoints,
+		AvailablePoints: user.AvailablePoints(),
+		Breakdown:       breakdown,
+	}, nil
+}
+
 func (s *pointService) GetPointHistory(userID primitive.ObjectID) ([]models.PointTransaction, error) {
 	return s.userRepo.GetPointTransactions(userID)
 }
+
+func (s *pointService) FindPointHistory(userID primitive.ObjectID, filter repositories.PointHistoryFilter, params utils.PaginationParams) ([]models.PointHistoryEntry, int, error) {
+	return s.userRepo.FindPointHistory(userID, filter, (params.Page-1)*params.Limit, params.Limit)
+}
+
+func (s *pointService) ExpirePoints(now time.Time) (int, error) {
+	lots, err := s.lotRepo.FindDueForExpiry(now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, lot := range lots {
+		err := s.txManager.WithTransaction(func(ctx context.Context) error {
+			ledger := s.ledger.withContext(ctx)
+
+			// Baca ulang lot di dalam transaksi, sisa point bisa sudah terpakai
+			current, err := ledger.lotRepo.FindByID(lot.ID)
+			if err != nil {
+				return err
+			}
+			if current.Expired || current.Remaining <= 0 {
+				return nil
+			}
+
+			_, err = ledger.expire(current)
+			return err
+		})
+		if err != nil {
+			// Lanjutkan ke lot berikutnya, lot ini akan dicoba lagi pada run berikutnya
+			log.Printf("Failed to expire point lot %s: %v", lot.ID.Hex(), err)
+			continue
+		}
+
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (s *pointService) TransferPoints(senderID primitive.ObjectID, req TransferRequest) (*PointTransfer, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+	limits := settings.Transfer
+
+	if !limits.Enabled {
+		return nil, errors.New("point transfers are disabled")
+	}
+	if req.Amount < limits.MinAmount {
+		return nil, errors.New("transfer amount is below the minimum")
+	}
+	if limits.MaxAmount > 0 && req.Amount > limits.MaxAmount {
+		return nil, errors.New("transfer amount exceeds the maximum")
+	}
+
+	recipient, err := s.userRepo.FindByEmail(strings.TrimSpace(req.RecipientEmail))
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, errors.New("recipient not found")
+		}
+		return nil, err
+	}
+	if recipient.ID == senderID {
+		return nil, errors.New("cannot transfer points to yourself")
+	}
+
+	result := &PointTransfer{
+		TransferID:     primitive.NewObjectID().Hex(),
+		RecipientName:  recipient.Name,
+		RecipientEmail: recipient.Email,
+		Amount:         req.Amount,
+		Note:           req.Note,
+	}
+
+	err = s.txManager.WithTransaction(func(ctx context.Context) error {
+		ledger := s.ledger.withContext(ctx)
+
+		// Cek saldo dan batas tahunan di dalam transaksi agar transfer bersamaan tidak melewatinya
+		sender, err := ledger.userRepo.FindByID(senderID)
+		if err != nil {
+			return err
+		}
+		if sender.AvailablePoints() < req.Amount {
+			return errors.New("insufficient point balance")
+		}
+
+		if limits.AnnualLimit > 0 {
+			now := time.Now()
+			startOfYear := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+			sent, err := ledger.userRepo.SumPointTransactionsByType(senderID, models.TransactionTransferOut, startOfYear)
+			if err != nil {
+				return err
+			}
+			if -sent+req.Amount > limits.AnnualLimit {
+				return errors.New("annual transfer limit exceeded")
+			}
+		}
+
+		out, _, err := ledger.transfer(senderID, recipient.ID, req.Amount, result.TransferID, req.Note)
+		if err != nil {
+			return err
+		}
+
+		result.PointBalance = sender.PointBalance - req.Amount
+		result.Transaction = out
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *pointService) AdjustPoints(userID, adminID primitive.ObjectID, req AdjustmentRequest) (*PointAdjustment, error) {
+	if req.Amount == 0 {
+		return nil, errors.New("adjustment amount cannot be zero")
+	}
+
+	switch req.ReasonCode {
+	case AdjustmentReasonCorrection, AdjustmentReasonCompensation, AdjustmentReasonGoodwill, AdjustmentReasonPolicy, AdjustmentReasonOther:
+	default:
+		return nil, errors.New("invalid reason code")
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if comment == "" {
+		return nil, errors.New("comment is required")
+	}
+
+	result := &PointAdjustment{}
+	err := s.txManager.WithTransaction(func(ctx context.Context) error {
+		ledger := s.ledger.withContext(ctx)
+
+		user, err := ledger.userRepo.FindByID(userID)
+		if err != nil {
+			return err
+		}
+
+		// Point yang ditahan booking pending tidak boleh ikut terpakai oleh pengurangan
+		if user.AvailablePoints()+req.Amount < 0 && !req.AllowNegative {
+			return errors.New("adjustment would make available points negative")
+		}
+
+		transaction, err := ledger.adjust(userID, req.Amount, req.ReasonCode, comment, adminID)
+		if err != nil {
+			return err
+		}
+
+		result.PointBalance = user.PointBalance + req.Amount
+		result.AvailablePoints = user.AvailablePoints() + req.Amount
+		result.Transaction = transaction
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// PoolMember adalah anggota pool beserta point yang dapat dipakai untuk booking bersama
+type PoolMember struct {
+	UserID          primitive.ObjectID `json:"user_id"`
+	Name            string             `json:"name"`
+	Email           string             `json:"email"`
+	AvailablePoints int                `json:"available_points"`
+}
+
+// PoolDetails adalah pool beserta data anggotanya
+type PoolDetails struct {
+	*models.Pool
+	Members         []PoolMember `json:"members"`
+	AvailablePoints int          `json:"available_points"` // Total point semua anggota yang dapat dipakai
+}
+
+type PoolService interface {
+	// GetUserPool mengembalikan pool user beserta anggotanya, nil jika user belum bergabung dengan pool
+	GetUserPool(userID primitive.ObjectID) (*PoolDetails, error)
+	// FindUserPool mengembalikan pool user, nil jika user belum bergabung dengan pool
+	FindUserPool(userID primitive.ObjectID) (*models.Pool, error)
+	CreatePool(userID primitive.ObjectID, name string) (*models.Pool, error)
+	JoinPool(userID primitive.ObjectID, joinCode string) (*models.Pool, error)
+	LeavePool(userID primitive.ObjectID) error
+	// SetShareLimit mengatur point terbanyak milik user yang boleh dipakai anggota lain dalam satu
+	// booking. 0 berarti anggota lain tidak bisa memakai point user.
+	SetShareLimit(userID primitive.ObjectID, maxPoints int) (*models.Pool, error)
+}
+
+type poolService struct {
+	poolRepo repositories.PoolRepository
+	userRepo repositories.UserRepository
+}
+
+func NewPoolService(poolRepo repositories.PoolRepository, userRepo repositories.UserRepository) PoolService {
+	return &poolService{
+		poolRepo: poolRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *poolService) GetUserPool(userID primitive.ObjectID) (*PoolDetails, error) {
+	pool, err := s.poolRepo.FindByMemberID(userID)
+	if err != nil || pool == nil {
+		return nil, err
+	}
+
+	details := &PoolDetails{Pool: pool, Members: make([]PoolMember, 0, len(pool.MemberIDs))}
+	for _, memberID := range pool.MemberIDs {
+		user, err := s.userRepo.FindByID(memberID)
+		if err != nil {
+			return nil, err
+		}
+
+		details.Members = append(details.Members, PoolMember{
+			UserID:          user.ID,
+			Name:            user.Name,
+			Email:           user.Email,
+			AvailablePoints: user.AvailablePoints(),
+		})
+		details.AvailablePoints += user.AvailablePoints()
+	}
+
+	return details, nil
+}
+
+func (s *poolService) FindUserPool(userID primitive.ObjectID) (*models.Pool, error) {
+	return s.poolRepo.FindByMemberID(userID)
+}
+
+func (s *poolService) CreatePool(userID primitive.ObjectID, name string) (*models.Pool, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("pool name cannot be empty")
+	}
+
+	existing, err := s.poolRepo.FindByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user already belongs to a pool")
+	}
+
+	joinCode, err := newJoinCode()
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &models.Pool{
+		ID:        primitive.NewObjectID(),
+		Name:      name,
+		JoinCode:  joinCode,
+		MemberIDs: []primitive.ObjectID{userID},
+	}
+	if err := s.poolRepo.Create(pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func (s *poolService) JoinPool(userID primitive.ObjectID, joinCode string) (*models.Pool, error) {
+	existing, err := s.poolRepo.FindByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, errors.New("user already belongs to a pool")
+	}
+
+	// Bergabung dengan update bersyarat, pemeriksaan di atas hanya untuk pesan error yang jelas
+	return s.poolRepo.AddMemberByJoinCode(strings.ToUpper(strings.TrimSpace(joinCode)), userID)
+}
+
+func (s *poolService) LeavePool(userID primitive.ObjectID) error {
+	pool, err := s.poolRepo.FindByMemberID(userID)
+	if err != nil {
+		return err
+	}
+	if pool == nil {
+		return errors.New("user does not belong to a pool")
+	}
+
+	// Pool tanpa anggota tersisa ikut dihapus
+	return s.poolRepo.RemoveMember(pool.ID, userID)
+}
+
+func (s *poolService) SetShareLimit(userID primitive.ObjectID, maxPoints int) (*models.Pool, error) {
+	if maxPoints < 0 {
+		return nil, errors.New("share limit cannot be negative")
+	}
+
+	pool, err := s.poolRepo.FindByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, errors.New("user does not belong to a pool")
+	}
+
+	if err := s.poolRepo.SetShareLimit(pool.ID, userID, maxPoints); err != nil {
+		return nil, err
+	}
+
+	return s.poolRepo.FindByID(pool.ID)
+}
+
+// newJoinCode membuat kode acak yang dibagikan kepada calon anggota pool
+func newJoinCode() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
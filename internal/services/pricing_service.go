@@ -0,0 +1,449 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// Sumber biaya point suatu hari pada kalender
+const (
+	CalendarSourceSpecial   = "special"   // DateRule untuk tanggal tersebut
+	CalendarSourceRecurring = "recurring" // RecurringDateRule yang cocok
+	CalendarSourceDefault   = "default"   // Biaya weekend/hari biasa dari pengaturan
+)
+
+// maxCalendarDays membatasi panjang rentang tanggal yang dihitung sekaligus
+const maxCalendarDays = 366
+
+// CalendarDay adalah biaya point satu hari beserta aturan yang menentukannya
+type CalendarDay struct {
+	Date      time.Time           `json:"date"`
+	DayType   string              `json:"day_type"` // "regular", "weekend", "holiday"
+	PointCost int                 `json:"point_cost"`
+	Name      string              `json:"name,omitempty"`
+	Source    string              `json:"source"`
+	RuleID    *primitive.ObjectID `json:"rule_id,omitempty"`
+}
+
+// DailyPointDetail godoc
+// @Description Detail biaya point per malam dalam pemesanan
+type DailyPointDetail struct {
+	Date      time.Time // Tanggal
+	DayType   string    // Tipe hari (regular, weekend, holiday)
+	PointCost int       // Biaya point
+	Name      string    // Nama hari libur (jika ada)
+
+	BasePointCost   int                 // Biaya point tanggal sebelum pengali
+	HotelMultiplier float64             // Pengali biaya point hotel
+	RoomMultiplier  float64             // Pengali biaya point kamar
+	Source          string              // Sumber biaya tanggal (special, recurring, default)
+	RuleID          *primitive.ObjectID // Aturan tanggal yang menentukan biaya, nil untuk default
+	DiscountPoints  int                 // Potongan last-minute untuk malam ini
+}
+
+// StayQuote adalah hasil perhitungan biaya point satu kamar untuk satu periode menginap
+type StayQuote struct {
+	Subtotal    int // Jumlah biaya semua malam sebelum potongan
+	Discounts   []models.BookingDiscount
+	TotalPoints int // Subtotal dikurangi potongan
+	Nights      []DailyPointDetail
+}
+
+// PricingService adalah satu-satunya sumber perhitungan biaya point.
+// Quote, booking dan laporan harus menghitung biaya melalui service ini.
+type PricingService interface {
+	// GetCalendar mengembalikan biaya point setiap tanggal dari startDate sampai endDate (inklusif).
+	// DateRule tanggal tertentu menang atas aturan berulang, aturan berulang menang atas default.
+	// Jika hotel tidak nil, hari weekend hotel tersebut dipakai untuk biaya default.
+	GetCalendar(hotel *models.Hotel, startDate, endDate time.Time) ([]CalendarDay, error)
+	// GetCalendarAsOf sama dengan GetCalendar, tetapi DateRule tanggal tertentu diambil dari versi
+	// yang berlaku pada waktu asOf. Tanggal tanpa riwayat versi memakai DateRule saat ini.
+	GetCalendarAsOf(hotel *models.Hotel, startDate, endDate, asOf time.Time) ([]CalendarDay, error)
+	// QuoteStay menghitung biaya setiap malam dari checkIn sampai sebelum checkOut (tanggal kalender hotel)
+	// dengan pengali hotel dan kamar, lalu menerapkan potongan last-minute dan menginap lama.
+	QuoteStay(hotel *models.Hotel, room *models.Room, checkIn, checkOut time.Time) (*StayQuote, error)
+}
+
+type pricingService struct {
+	dateRepo        repositories.DateRepository
+	hotelRepo       repositories.HotelRepository
+	bookingRepo     repositories.BookingRepository
+	settingsService SettingsService
+}
+
+func NewPricingService(
+	dateRepo repositories.DateRepository,
+	hotelRepo repositories.HotelRepository,
+	bookingRepo repositories.BookingRepository,
+	settingsService SettingsService,
+) PricingService {
+	return &pricingService{
+		dateRepo:        dateRepo,
+		hotelRepo:       hotelRepo,
+		bookingRepo:     bookingRepo,
+		settingsService: settingsService,
+	}
+}
+
+func (s *pricingService) GetCalendar(hotel *models.Hotel, startDate, endDate time.Time) ([]CalendarDay, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendar(hotel, startDate, endDate, settings, nil)
+}
+
+func (s *pricingService) GetCalendarAsOf(hotel *models.Hotel, startDate, endDate, asOf time.Time) ([]CalendarDay, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calendar(hotel, startDate, endDate, settings, &asOf)
+}
+
+// calendar menghitung biaya point setiap tanggal dengan pengaturan yang sudah dimuat.
+// Jika asOf tidak nil, DateRule diambil dari versi yang berlaku pada waktu tersebut.
+func (s *pricingService) calendar(hotel *models.Hotel, startDate, endDate time.Time, settings *models.Settings, asOf *time.Time) ([]CalendarDay, error) {
+	startDate = models.CalendarDate(startDate)
+	endDate = models.CalendarDate(endDate)
+	if startDate.After(endDate) {
+		return nil, errors.New("start date cannot be after end date")
+	}
+	if endDate.Sub(startDate) >= maxCalendarDays*24*time.Hour {
+		return nil, errors.New("date range cannot exceed 366 days")
+	}
+
+	// Semua aturan rentang ini dimuat sekali
+	rules, err := s.dateRepo.FindDateRules(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	specialDates := make(map[string]models.DateRule, len(rules))
+	for _, rule := range rules {
+		specialDates[rule.Date.Format("2006-01-02")] = rule
+	}
+
+	if asOf != nil {
+		if err := s.applyDateRuleVersions(specialDates, startDate, endDate, *asOf); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sudah urut dari prioritas tertinggi, aturan pertama yang cocok menang
+	recurringRules, err := s.dateRepo.FindRecurringRules()
+	if err != nil {
+		return nil, err
+	}
+
+	pricing := settings.Pricing
+	if hotel != nil && len(hotel.WeekendDays) > 0 {
+		pricing.WeekendDays = hotel.WeekendDays
+	}
+
+	var days []CalendarDay
+	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
+		if rule, exists := specialDates[d.Format("2006-01-02")]; exists {
+			ruleID := rule.ID
+			days = append(days, CalendarDay{
+				Date:      d,
+				DayType:   rule.Type,
+				PointCost: rule.PointCost,
+				Name:      rule.Name,
+				Source:    CalendarSourceSpecial,
+				RuleID:    &ruleID,
+			})
+			continue
+		}
+
+		day := defaultCalendarDay(d, pricing)
+		for i := range recurringRules {
+			if recurringRules[i].Matches(d) {
+				ruleID := recurringRules[i].ID
+				day = CalendarDay{
+					Date:      d,
+					DayType:   recurringRules[i].Type,
+					PointCost: recurringRules[i].PointCost,
+					Name:      recurringRules[i].Name,
+					Source:    CalendarSourceRecurring,
+					RuleID:    &ruleID,
+				}
+				break
+			}
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func (s *pricingService) QuoteStay(hotel *models.Hotel, room *models.Room, checkIn, checkOut time.Time) (*StayQuote, error) {
+	startDate := models.CalendarDate(checkIn)
+	endDate := models.CalendarDate(checkOut)
+	if startDate.After(endDate) {
+		return nil, errors.New("check-in date cannot be after check-out date")
+	}
+
+	quote := &StayQuote{}
+	if !startDate.Before(endDate) {
+		return quote, nil
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	// Malam terakhir adalah sehari sebelum check-out
+	days, err := s.calendar(hotel, startDate, endDate.AddDate(0, 0, -1), settings, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hotelMultiplier := hotel.EffectivePointMultiplier()
+	roomMultiplier := room.EffectivePointMultiplier()
+
+	for _, day := range days {
+		pointCost := applyPointMultipliers(day.PointCost, hotelMultiplier, roomMultiplier)
+		quote.Subtotal += pointCost
+		quote.Nights = append(quote.Nights, DailyPointDetail{
+			Date:            day.Date,
+			DayType:         day.DayType,
+			PointCost:       pointCost,
+			Name:            day.Name,
+			BasePointCost:   day.PointCost,
+			HotelMultiplier: hotelMultiplier,
+			RoomMultiplier:  roomMultiplier,
+			Source:          day.Source,
+			RuleID:          day.RuleID,
+		})
+	}
+
+	quote.TotalPoints = quote.Subtotal
+	if hotel.LastMinuteDiscount != nil {
+		discount, err := s.lastMinuteDiscount(hotel, quote.Nights, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if discount != nil {
+			quote.Discounts = append(quote.Discounts, *discount)
+			quote.TotalPoints -= discount.Points
+		}
+	}
+
+	if discount := stayDiscount(settings.StayDiscounts, quote.Nights); discount != nil {
+		quote.Discounts = append(quote.Discounts, *discount)
+		quote.TotalPoints -= discount.Points
+	}
+
+	return quote, nil
+}
+
+// applyDateRuleVersions mengganti DateRule setiap tanggal yang memiliki riwayat versi dengan
+// versi terakhir yang dibuat paling lambat asOf. Tanggal yang belum memiliki versi pada
+// waktu itu, atau versi terakhirnya deleted, dianggap tidak memiliki DateRule. Rule dari
+// sebelum versioning mendapat versi awal saat pertama kali diubah, lihat recordBaselineVersion.
+func (s *pricingService) applyDateRuleVersions(specialDates map[string]models.DateRule, startDate, endDate, asOf time.Time) error {
+	// Urut dari perubahan terlama, versi terakhir yang berlaku menimpa versi sebelumnya
+	versions, err := s.dateRepo.FindDateRuleVersionsInRange(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	effective := make(map[string]*models.DateRuleVersion)
+	for i := range versions {
+		key := versions[i].Date.Format("2006-01-02")
+		if _, exists := effective[key]; !exists {
+			effective[key] = nil
+		}
+		if !versions[i].ChangedAt.After(asOf) {
+			effective[key] = &versions[i]
+		}
+	}
+
+	for key, version := range effective {
+		if version == nil || version.Action == models.DateRuleDeleted {
+			delete(specialDates, key)
+			continue
+		}
+		specialDates[key] = models.DateRule{
+			ID:        version.RuleID,
+			Date:      version.Date,
+			Type:      version.Type,
+			PointCost: version.PointCost,
+			Name:      version.Name,
+			Version:   version.Version,
+			UpdatedBy: version.ChangedBy,
+			UpdatedAt: version.ChangedAt,
+		}
+	}
+
+	return nil
+}
+
+// defaultCalendarDay menentukan biaya hari tanpa aturan khusus dari pengaturan biaya point
+func defaultCalendarDay(d time.Time, pricing models.PricingSettings) CalendarDay {
+	if pricing.IsWeekend(d.Weekday()) {
+		return CalendarDay{Date: d, DayType: "weekend", PointCost: pricing.WeekendCost, Source: CalendarSourceDefault}
+	}
+
+	return CalendarDay{Date: d, DayType: "regular", PointCost: pricing.WeekdayCost, Source: CalendarSourceDefault}
+}
+
+// applyPointMultipliers menerapkan pengali hotel dan kamar pada biaya point tanggal.
+// Hasil dibulatkan ke point terdekat dan minimal 1 point per malam.
+func applyPointMultipliers(baseCost int, hotelMultiplier, roomMultiplier float64) int {
+	cost := int(math.Round(float64(baseCost) * hotelMultiplier * roomMultiplier))
+	if cost < 1 {
+		return 1
+	}
+	return cost
+}
+
+// lastMinuteDiscount memotong biaya malam dalam WithinDays hari dari now yang okupansi hotelnya
+// di bawah batas. Potongan tiap malam dicatat pada DiscountPoints.
+func (s *pricingService) lastMinuteDiscount(hotel *models.Hotel, nights []DailyPointDetail, now time.Time) (*models.BookingDiscount, error) {
+	rule := hotel.LastMinuteDiscount
+	today := hotel.LocalDate(now)
+	cutoff := today.AddDate(0, 0, rule.WithinDays)
+
+	var eligible []int
+	for i := range nights {
+		if nights[i].Date.Before(cutoff) {
+			eligible = append(eligible, i)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil, nil
+	}
+
+	dates := make([]time.Time, 0, len(eligible))
+	for _, i := range eligible {
+		dates = append(dates, nights[i].Date)
+	}
+
+	occupancy, err := s.hotelOccupancyRates(hotel, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	discount := &models.BookingDiscount{Type: models.DiscountLastMinute}
+	discountedNights := 0
+	for _, i := range eligible {
+		if occupancy[nights[i].Date.Format("2006-01-02")]*100 >= float64(rule.OccupancyBelow) {
+			continue
+		}
+
+		nights[i].DiscountPoints = nights[i].PointCost * rule.PercentOff / 100
+		discount.Points += nights[i].DiscountPoints
+		discountedNights++
+	}
+
+	if discount.Points == 0 {
+		return nil, nil
+	}
+
+	discount.Description = fmt.Sprintf("Last minute: %d%% off %d night(s) below %d%% occupancy", rule.PercentOff, discountedNights, rule.OccupancyBelow)
+	return discount, nil
+}
+
+// hotelOccupancyRates menghitung okupansi hotel (0-1) untuk setiap malam: jumlah booking
+// yang tidak dibatalkan dan mencakup malam tersebut dibagi jumlah kamar
+func (s *pricingService) hotelOccupancyRates(hotel *models.Hotel, nights []time.Time) (map[string]float64, error) {
+	rooms, err := s.hotelRepo.FindRoomsByHotelID(hotel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64, len(nights))
+	if len(rooms) == 0 || len(nights) == 0 {
+		return rates, nil
+	}
+
+	// Hanya booking yang beririsan dengan malam-malam yang dihitung, dari tengah malam lokal
+	// malam pertama sampai tengah malam lokal setelah malam terakhir
+	first, last := nights[0], nights[0]
+	for _, night := range nights[1:] {
+		if night.Before(first) {
+			first = night
+		}
+		if night.After(last) {
+			last = night
+		}
+	}
+	loc := hotel.Location()
+	from := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	to := time.Date(last.Year(), last.Month(), last.Day()+1, 0, 0, 0, 0, loc)
+
+	bookings, err := s.bookingRepo.FindActiveByHotelIDAndDateRange(hotel.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, night := range nights {
+		booked := 0
+		for _, booking := range bookings {
+			// Booking mencakup malam dari tanggal check-in sampai sebelum tanggal check-out di zona waktu hotel
+			checkIn := hotel.LocalDate(booking.CheckIn)
+			checkOut := hotel.LocalDate(booking.CheckOut)
+			if !night.Before(checkIn) && night.Before(checkOut) {
+				booked++
+			}
+		}
+
+		rates[night.Format("2006-01-02")] = float64(booked) / float64(len(rooms))
+	}
+
+	return rates, nil
+}
+
+// stayDiscount mengembalikan potongan menginap lama dengan MinNights tertinggi yang terpenuhi,
+// dihitung dari biaya malam setelah potongan last-minute. Nil jika tidak ada aturan yang berlaku.
+func stayDiscount(discounts []models.StayDiscount, nights []DailyPointDetail) *models.BookingDiscount {
+	var rule *models.StayDiscount
+	for i := range discounts {
+		if discounts[i].MinNights <= len(nights) && (rule == nil || discounts[i].MinNights > rule.MinNights) {
+			rule = &discounts[i]
+		}
+	}
+	if rule == nil {
+		return nil
+	}
+
+	discount := &models.BookingDiscount{Type: models.DiscountLengthOfStay}
+	if rule.FreeNights > 0 {
+		// Malam termurah yang digratiskan
+		costs := make([]int, 0, len(nights))
+		for _, night := range nights {
+			costs = append(costs, night.PointCost-night.DiscountPoints)
+		}
+		sort.Ints(costs)
+		for _, cost := range costs[:rule.FreeNights] {
+			discount.Points += cost
+		}
+		discount.Description = fmt.Sprintf("Stay %d+ nights: %d free night(s)", rule.MinNights, rule.FreeNights)
+	} else {
+		subtotal := 0
+		for _, night := range nights {
+			subtotal += night.PointCost - night.DiscountPoints
+		}
+		discount.Points = subtotal * rule.PercentOff / 100
+		discount.Description = fmt.Sprintf("Stay %d+ nights: %d%% off", rule.MinNights, rule.PercentOff)
+	}
+
+	if discount.Points == 0 {
+		return nil
+	}
+
+	return discount
+}
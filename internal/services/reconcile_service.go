@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// PointDrift adalah hasil rekonsiliasi saldo point satu user
+type PointDrift struct {
+	UserID    primitive.ObjectID `json:"user_id"`
+	Name      string             `json:"name"`
+	Email     string             `json:"email"`
+	Balance   int                `json:"point_balance"`
+	LedgerSum int                `json:"ledger_sum"`
+	Drift     int                `json:"drift"` // point_balance - ledger_sum
+	Repaired  bool               `json:"repaired"`
+}
+
+// ReconcileReport adalah laporan rekonsiliasi saldo terhadap point_transactions
+type ReconcileReport struct {
+	GeneratedAt    time.Time    `json:"generated_at"`
+	UsersChecked   int          `json:"users_checked"`
+	UsersWithDrift int          `json:"users_with_drift"`
+	TotalDrift     int          `json:"total_drift"`
+	DryRun         bool         `json:"dry_run"`
+	Repaired       int          `json:"repaired"`
+	Entries        []PointDrift `json:"entries"`
+}
+
+type ReconcileService interface {
+	// Reconcile menghitung drift setiap user, includeAll juga menyertakan user tanpa drift
+	Reconcile(includeAll bool) (*ReconcileReport, error)
+	// Repair menulis transaksi adjustment untuk setiap drift, dryRun hanya melaporkan
+	Repair(dryRun bool) (*ReconcileReport, error)
+}
+
+type reconcileService struct {
+	userRepo  repositories.UserRepository
+	txManager repositories.TransactionManager
+}
+
+func NewReconcileService(userRepo repositories.UserRepository, txManager repositories.TransactionManager) ReconcileService {
+	return &reconcileService{
+		userRepo:  userRepo,
+		txManager: txManager,
+	}
+}
+
+func (s *reconcileService) Reconcile(includeAll bool) (*ReconcileReport, error) {
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	sums, err := s.userRepo.SumPointTransactionsByUser()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{
+		GeneratedAt:  time.Now(),
+		UsersChecked: len(users),
+		Entries:      []PointDrift{},
+	}
+
+	for _, user := range users {
+		entry := PointDrift{
+			UserID:    user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Balance:   user.PointBalance,
+			LedgerSum: sums[user.ID],
+		}
+		entry.Drift = entry.Balance - entry.LedgerSum
+
+		if entry.Drift != 0 {
+			report.UsersWithDrift++
+			report.TotalDrift += entry.Drift
+		}
+
+		if entry.Drift != 0 || includeAll {
+			report.Entries = append(report.Entries, entry)
+		}
+	}
+
+	return report, nil
+}
+
+func (s *reconcileService) Repair(dryRun bool) (*ReconcileReport, error) {
+	report, err := s.Reconcile(false)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DryRun = dryRun
+	if dryRun {
+		return report, nil
+	}
+
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+
+		err := s.txManager.WithTransaction(func(ctx context.Context) error {
+			userRepo := s.userRepo.WithContext(ctx)
+
+			// Hitung ulang di dalam transaksi, saldo bisa berubah sejak laporan dibuat
+			user, err := userRepo.FindByID(entry.UserID)
+			if err != nil {
+				return err
+			}
+
+			ledgerSum, err := userRepo.SumPointTransactions(entry.UserID)
+			if err != nil {
+				return err
+			}
+
+			drift := user.PointBalance - ledgerSum
+			if drift == 0 {
+				return nil
+			}
+
+			// Saldo dianggap benar, ledger dikoreksi agar jumlahnya sama dengan saldo
+			transaction := &models.PointTransaction{
+				ID:        primitive.NewObjectID(),
+				UserID:    entry.UserID,
+				Amount:    drift,
+				Type:      models.TransactionAdjustment,
+				Reference: "reconcile",
+				CreatedAt: time.Now(),
+			}
+			if err := userRepo.CreatePointTransaction(transaction); err != nil {
+				return err
+			}
+
+			entry.Balance = user.PointBalance
+			entry.LedgerSum = ledgerSum
+			entry.Drift = drift
+			entry.Repaired = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.Repaired {
+			report.Repaired++
+		}
+	}
+
+	return report, nil
+}
+
+// WriteCSV menulis entri laporan dalam format CSV
+func (r *ReconcileReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"user_id", "name", "email", "point_balance", "ledger_sum", "drift", "repaired"}); err != nil {
+		return err
+	}
+
+	for _, entry := range r.Entries {
+		record := []string{
+			entry.UserID.Hex(),
+			entry.Name,
+			entry.Email,
+			strconv.Itoa(entry.Balance),
+			strconv.Itoa(entry.LedgerSum),
+			strconv.Itoa(entry.Drift),
+			strconv.FormatBool(entry.Repaired),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+type SettingsService interface {
+	// GetSettings mengembalikan pengaturan tersimpan, atau nilai default jika belum ada
+	GetSettings() (*models.Settings, error)
+	UpdateTransferSettings(transfer models.TransferSettings, updatedBy primitive.ObjectID) (*models.Settings, error)
+	UpdateCarryOverSettings(carryOver models.CarryOverSettings, updatedBy primitive.ObjectID) (*models.Settings, error)
+	UpdatePricingSettings(pricing models.PricingSettings, updatedBy primitive.ObjectID) (*models.Settings, error)
+	UpdateStayDiscounts(discounts []models.StayDiscount, updatedBy primitive.ObjectID) (*models.Settings, error)
+}
+
+type settingsService struct {
+	settingsRepo repositories.SettingsRepository
+}
+
+func NewSettingsService(settingsRepo repositories.SettingsRepository) SettingsService {
+	return &settingsService{
+		settingsRepo: settingsRepo,
+	}
+}
+
+// defaultSettings adalah pengaturan yang berlaku sebelum admin menyimpan perubahan
+func defaultSettings() *models.Settings {
+	return &models.Settings{
+		ID: models.SettingsID,
+		Transfer: models.TransferSettings{
+			Enabled:   true,
+			MinAmount: 1,
+		},
+		CarryOver: models.CarryOverSettings{
+			Mode: models.CarryOverNone,
+		},
+		Pricing: defaultPricingSettings(),
+	}
+}
+
+// defaultPricingSettings adalah biaya point awal: Sabtu dan Minggu 2 point, hari biasa 1 point
+func defaultPricingSettings() models.PricingSettings {
+	return models.PricingSettings{
+		WeekendDays:  []time.Weekday{time.Saturday, time.Sunday},
+		WeekdayCost:  1,
+		WeekendCost:  2,
+		MaxPointCost: 3,
+	}
+}
+
+func (s *settingsService) GetSettings() (*models.Settings, error) {
+	settings, err := s.settingsRepo.Get()
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return defaultSettings(), nil
+	}
+
+	normalizeSettings(settings)
+	return settings, nil
+}
+
+// normalizeSettings mengisi bagian yang belum ada pada pengaturan yang disimpan versi lama
+func normalizeSettings(settings *models.Settings) {
+	// Pengaturan yang disimpan sebelum carry-over ada belum memiliki mode
+	if settings.CarryOver.Mode == "" {
+		settings.CarryOver.Mode = models.CarryOverNone
+	}
+
+	// Pengaturan yang disimpan sebelum biaya point dapat diatur memakai default
+	if settings.Pricing.MaxPointCost == 0 {
+		settings.Pricing = defaultPricingSettings()
+	}
+}
+
+func (s *settingsService) UpdateTransferSettings(transfer models.TransferSettings, updatedBy primitive.ObjectID) (*models.Settings, error) {
+	if transfer.MinAmount < 1 {
+		return nil, errors.New("min_amount must be at least 1")
+	}
+	if transfer.MaxAmount < 0 || transfer.AnnualLimit < 0 {
+		return nil, errors.New("limits cannot be negative")
+	}
+	if transfer.MaxAmount > 0 && transfer.MaxAmount < transfer.MinAmount {
+		return nil, errors.New("max_amount cannot be less than min_amount")
+	}
+
+	return s.updateSection("transfer", transfer, updatedBy)
+}
+
+func (s *settingsService) UpdateCarryOverSettings(carryOver models.CarryOverSettings, updatedBy primitive.ObjectID) (*models.Settings, error) {
+	switch carryOver.Mode {
+	case models.CarryOverNone:
+		carryOver.Cap = 0
+		carryOver.Percentage = 0
+	case models.CarryOverCap:
+		if carryOver.Cap < 0 {
+			return nil, errors.New("cap cannot be negative")
+		}
+		carryOver.Percentage = 0
+	case models.CarryOverPercentage:
+		if carryOver.Percentage < 0 || carryOver.Percentage > 100 {
+			return nil, errors.New("percentage must be between 0 and 100")
+		}
+		carryOver.Cap = 0
+	default:
+		return nil, errors.New("invalid carry-over mode")
+	}
+
+	return s.updateSection("carry_over", carryOver, updatedBy)
+}
+
+func (s *settingsService) UpdatePricingSettings(pricing models.PricingSettings, updatedBy primitive.ObjectID) (*models.Settings, error) {
+	if pricing.WeekdayCost < 1 || pricing.WeekendCost < 1 {
+		return nil, errors.New("weekday_cost and weekend_cost must be at least 1")
+	}
+	if pricing.MaxPointCost < pricing.WeekdayCost || pricing.MaxPointCost < pricing.WeekendCost {
+		return nil, errors.New("max_point_cost cannot be less than weekday_cost or weekend_cost")
+	}
+
+	weekendDays, err := normalizeWeekendDays(pricing.WeekendDays)
+	if err != nil {
+		return nil, err
+	}
+	pricing.WeekendDays = weekendDays
+
+	return s.updateSection("pricing", pricing, updatedBy)
+}
+
+func (s *settingsService) UpdateStayDiscounts(discounts []models.StayDiscount, updatedBy primitive.ObjectID) (*models.Settings, error) {
+	minNights := make(map[int]bool, len(discounts))
+	for _, discount := range discounts {
+		if discount.MinNights < 2 {
+			return nil, errors.New("min_nights must be at least 2")
+		}
+		if minNights[discount.MinNights] {
+			return nil, errors.New("only one discount per min_nights is allowed")
+		}
+		minNights[discount.MinNights] = true
+
+		if (discount.FreeNights > 0) == (discount.PercentOff > 0) {
+			return nil, errors.New("set either free_nights or percent_off")
+		}
+		if discount.FreeNights < 0 || discount.FreeNights >= discount.MinNights {
+			return nil, errors.New("free_nights must be less than min_nights")
+		}
+		if discount.PercentOff < 0 || discount.PercentOff > 100 {
+			return nil, errors.New("percent_off must be between 1 and 100")
+		}
+	}
+
+	sorted := make([]models.StayDiscount, len(discounts))
+	copy(sorted, discounts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].MinNights < sorted[j].MinNights })
+
+	return s.updateSection("stay_discounts", sorted, updatedBy)
+}
+
+// updateSection hanya mengubah satu bagian pengaturan agar perubahan admin lain pada bagian
+// berbeda yang terjadi bersamaan tidak tertimpa
+func (s *settingsService) updateSection(section string, value interface{}, updatedBy primitive.ObjectID) (*models.Settings, error) {
+	settings, err := s.settingsRepo.UpdateSection(section, value, updatedBy, defaultSettings())
+	if err != nil {
+		return nil, err
+	}
+
+	normalizeSettings(settings)
+	return settings, nil
+}
+
+// normalizeWeekendDays memvalidasi hari weekend dan membuang duplikat
+func normalizeWeekendDays(days []time.Weekday) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool, len(days))
+	normalized := make([]time.Weekday, 0, len(days))
+	for _, day := range days {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, errors.New("weekend days must be between 0 (Sunday) and 6 (Saturday)")
+		}
+		if !seen[day] {
+			seen[day] = true
+			normalized = append(normalized, day)
+		}
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+	"hotel-point-app/pkg/pdf"
+)
+
+// StatementLine adalah satu transaksi pada laporan point
+type StatementLine struct {
+	Date        time.Time `json:"date"`
+	Type        string    `json:"type"`
+	Description string    `json:"description"`
+	Reference   string    `json:"reference"`
+	Amount      int       `json:"amount"`
+	Balance     int       `json:"balance"` // Saldo setelah transaksi
+}
+
+// PointStatement adalah laporan aktivitas point user selama satu tahun
+type PointStatement struct {
+	UserName       string          `json:"user_name"`
+	UserEmail      string          `json:"user_email"`
+	Year           int             `json:"year"`
+	OpeningBalance int             `json:"opening_balance"`
+	ClosingBalance int             `json:"closing_balance"`
+	Lines          []StatementLine `json:"lines"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+type StatementService interface {
+	GenerateStatement(userID primitive.ObjectID, year int) (*PointStatement, error)
+}
+
+type statementService struct {
+	userRepo    repositories.UserRepository
+	bookingRepo repositories.BookingRepository
+	hotelRepo   repositories.HotelRepository
+}
+
+func NewStatementService(
+	userRepo repositories.UserRepository,
+	bookingRepo repositories.BookingRepository,
+	hotelRepo repositories.HotelRepository,
+) StatementService {
+	return &statementService{
+		userRepo:    userRepo,
+		bookingRepo: bookingRepo,
+		hotelRepo:   hotelRepo,
+	}
+}
+
+func (s *statementService) GenerateStatement(userID primitive.ObjectID, year int) (*PointStatement, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.userRepo.GetPointTransactions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetPointTransactions mengembalikan transaksi terbaru lebih dulu
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+	})
+
+	start := yearStart(year)
+	end := yearEnd(year)
+
+	statement := &PointStatement{
+		UserName:    user.Name,
+		UserEmail:   user.Email,
+		Year:        year,
+		Lines:       []StatementLine{},
+		GeneratedAt: time.Now(),
+	}
+
+	balance := 0
+	for _, transaction := range transactions {
+		if !transaction.CreatedAt.Before(end) {
+			break
+		}
+
+		balance += transaction.Amount
+		if transaction.CreatedAt.Before(start) {
+			statement.OpeningBalance = balance
+			continue
+		}
+
+		statement.Lines = append(statement.Lines, StatementLine{
+			Date:        transaction.CreatedAt,
+			Type:        transaction.Type,
+			Description: s.describe(&transaction),
+			Reference:   transaction.Reference,
+			Amount:      transaction.Amount,
+			Balance:     balance,
+		})
+	}
+	statement.ClosingBalance = balance
+
+	return statement, nil
+}
+
+// describe membuat keterangan transaksi, mis. nama hotel untuk transaksi booking
+func (s *statementService) describe(transaction *models.PointTransaction) string {
+	switch transaction.Type {
+	case models.TransactionBookingDeduction, models.TransactionBookingRefund,
+		models.TransactionBookingReactivation, models.TransactionBookingDeletionRefund:
+		bookingID, err := primitive.ObjectIDFromHex(transaction.Reference)
+		if err != nil {
+			return "Booking"
+		}
+
+		booking, err := s.bookingRepo.FindByID(bookingID)
+		if err != nil {
+			return "Booking (deleted)"
+		}
+
+		hotelName := "Unknown hotel"
+		if hotel, err := s.hotelRepo.FindByID(booking.HotelID); err == nil {
+			hotelName = hotel.Name
+		}
+
+		return fmt.Sprintf("%s, %s - %s", hotelName, booking.CheckIn.Format("2006-01-02"), booking.CheckOut.Format("2006-01-02"))
+
+	case models.TransactionTransferOut, models.TransactionTransferIn:
+		direction := "Transfer to"
+		if transaction.Type == models.TransactionTransferIn {
+			direction = "Transfer from"
+		}
+
+		counterparty := "unknown user"
+		if transaction.CounterpartyID != nil {
+			if user, err := s.userRepo.FindByID(*transaction.CounterpartyID); err == nil {
+				counterparty = user.Name
+			}
+		}
+
+		if transaction.Note != "" {
+			return fmt.Sprintf("%s %s: %s", direction, counterparty, transaction.Note)
+		}
+		return direction + " " + counterparty
+
+	case models.TransactionAnnualGrant:
+		return "Point grant " + transaction.Reference
+	case models.TransactionProratedGrant:
+		return "Pro-rated point grant " + transaction.Reference
+	case models.TransactionGrantTopUp:
+		return "Point grant top-up " + transaction.Reference
+	case models.TransactionPointExpiry:
+		return "Expired points"
+	case models.TransactionYearEndForfeit:
+		return "Year-end forfeit " + transaction.Reference
+	case models.TransactionAdminAdjustment:
+		return "Adjustment (" + transaction.Reference + "): " + transaction.Note
+	case models.TransactionAdjustment:
+		return "Ledger correction"
+	}
+
+	return transaction.Type
+}
+
+// WriteCSV menulis laporan dalam format CSV
+func (st *PointStatement) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	records := [][]string{
+		{"date", "type", "description", "reference", "amount", "balance"},
+		{yearStart(st.Year).Format("2006-01-02"), "opening_balance", "Opening balance", "", "", strconv.Itoa(st.OpeningBalance)},
+	}
+	for _, line := range st.Lines {
+		records = append(records, []string{
+			line.Date.Format("2006-01-02 15:04"),
+			line.Type,
+			line.Description,
+			line.Reference,
+			strconv.Itoa(line.Amount),
+			strconv.Itoa(line.Balance),
+		})
+	}
+	records = append(records, []string{
+		yearEnd(st.Year).AddDate(0, 0, -1).Format("2006-01-02"), "closing_balance", "Closing balance", "", "", strconv.Itoa(st.ClosingBalance),
+	})
+
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// WritePDF menulis laporan sebagai dokumen PDF
+func (st *PointStatement) WritePDF(w io.Writer) error {
+	const (
+		margin     = 50.0
+		lineHeight = 14.0
+		fontSize   = 9.0
+	)
+
+	// Posisi x kolom: tanggal, tipe, keterangan, jumlah, saldo
+	columns := []float64{margin, margin + 75, margin + 185, margin + 420, margin + 465}
+
+	doc := pdf.New()
+	y := 0.0
+
+	newPage := func() {
+		doc.AddPage()
+		y = pdf.PageHeight - margin
+
+		if doc.PageCount() == 1 {
+			doc.Text(margin, y, pdf.FontBold, 16, fmt.Sprintf("Point Statement %d", st.Year))
+			y -= 22
+			doc.Text(margin, y, pdf.FontRegular, 10, fmt.Sprintf("%s <%s>", st.UserName, st.UserEmail))
+			y -= lineHeight
+			doc.Text(margin, y, pdf.FontRegular, 10, "Generated "+st.GeneratedAt.Format("2006-01-02 15:04"))
+			y -= 2 * lineHeight
+			doc.Text(margin, y, pdf.FontBold, 10, fmt.Sprintf("Opening balance: %d", st.OpeningBalance))
+			y -= 2 * lineHeight
+		}
+
+		headers := []string{"Date", "Type", "Description", "Amount", "Balance"}
+		for i, header := range headers {
+			doc.Text(columns[i], y, pdf.FontBold, fontSize, header)
+		}
+		doc.Line(margin, y-4, pdf.PageWidth-margin, y-4)
+		y -= lineHeight + 2
+	}
+
+	newPage()
+	for _, line := range st.Lines {
+		if y < margin+lineHeight {
+			newPage()
+		}
+
+		doc.Text(columns[0], y, pdf.FontRegular, fontSize, line.Date.Format("2006-01-02"))
+		doc.Text(columns[1], y, pdf.FontRegular, fontSize, truncate(line.Type, 20))
+		doc.Text(columns[2], y, pdf.FontRegular, fontSize, truncate(line.Description, 48))
+		doc.Text(columns[3], y, pdf.FontRegular, fontSize, strconv.Itoa(line.Amount))
+		doc.Text(columns[4], y, pdf.FontRegular, fontSize, strconv.Itoa(line.Balance))
+		y -= lineHeight
+	}
+
+	if y < margin+2*lineHeight {
+		newPage()
+	}
+	doc.Line(margin, y+lineHeight-4, pdf.PageWidth-margin, y+lineHeight-4)
+	y -= lineHeight / 2
+	doc.Text(margin, y, pdf.FontBold, 10, fmt.Sprintf("Closing balance: %d", st.ClosingBalance))
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+// yearStart adalah awal tahun year
+func yearStart(year int) time.Time {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+}
+
+// truncate memotong text menjadi paling banyak limit karakter
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
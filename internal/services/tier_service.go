@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+type TierService interface {
+	GetTiers() ([]models.Tier, error)
+	GetTierByID(id primitive.ObjectID) (*models.Tier, error)
+	// ResolveTier mengembalikan tier yang berlaku untuk user, nil jika tidak ada yang cocok
+	ResolveTier(user *models.User) (*models.Tier, error)
+
+	// Admin functions
+	CreateTier(tier *models.Tier) error
+	UpdateTier(tier *models.Tier) error
+	DeleteTier(id primitive.ObjectID) error
+	SetUserGrade(userID primitive.ObjectID, grade string) error
+}
+
+type tierService struct {
+	tierRepo repositories.TierRepository
+	userRepo repositories.UserRepository
+}
+
+func NewTierService(tierRepo repositories.TierRepository, userRepo repositories.UserRepository) TierService {
+	return &tierService{
+		tierRepo: tierRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *tierService) GetTiers() ([]models.Tier, error) {
+	return s.tierRepo.FindAll()
+}
+
+func (s *tierService) GetTierByID(id primitive.ObjectID) (*models.Tier, error) {
+	return s.tierRepo.FindByID(id)
+}
+
+func (s *tierService) ResolveTier(user *models.User) (*models.Tier, error) {
+	tiers, err := s.tierRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	years := yearsOfService(user, time.Now())
+
+	// Tier dengan syarat masa kerja tertinggi yang terpenuhi menang,
+	// tier khusus grade lebih diutamakan daripada tier untuk semua grade
+	var resolved *models.Tier
+	for i := range tiers {
+		tier := &tiers[i]
+		if years < tier.MinYearsOfService {
+			continue
+		}
+		if len(tier.Grades) > 0 && !slices.Contains(tier.Grades, user.Grade) {
+			continue
+		}
+
+		if resolved == nil ||
+			tier.MinYearsOfService > resolved.MinYearsOfService ||
+			(tier.MinYearsOfService == resolved.MinYearsOfService && len(tier.Grades) > 0 && len(resolved.Grades) == 0) {
+			resolved = tier
+		}
+	}
+
+	return resolved, nil
+}
+
+// Implementasi fungsi admin
+
+func (s *tierService) CreateTier(tier *models.Tier) error {
+	if err := validateTier(tier); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tier.CreatedAt = now
+	tier.UpdatedAt = now
+
+	if tier.ID.IsZero() {
+		tier.ID = primitive.NewObjectID()
+	}
+
+	return s.tierRepo.Create(tier)
+}
+
+func (s *tierService) UpdateTier(tier *models.Tier) error {
+	if tier.ID.IsZero() {
+		return errors.New("tier ID cannot be empty")
+	}
+
+	existing, err := s.tierRepo.FindByID(tier.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateTier(tier); err != nil {
+		return err
+	}
+
+	tier.CreatedAt = existing.CreatedAt
+	tier.UpdatedAt = time.Now()
+
+	return s.tierRepo.Update(tier)
+}
+
+func (s *tierService) DeleteTier(id primitive.ObjectID) error {
+	return s.tierRepo.Delete(id)
+}
+
+func (s *tierService) SetUserGrade(userID primitive.ObjectID, grade string) error {
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return err
+	}
+
+	return s.userRepo.UpdateGrade(userID, strings.TrimSpace(grade))
+}
+
+// validateTier memeriksa field tier dan merapikan daftar grade
+func validateTier(tier *models.Tier) error {
+	tier.Name = strings.TrimSpace(tier.Name)
+	if tier.Name == "" {
+		return errors.New("tier name cannot be empty")
+	}
+
+	if tier.AnnualPoints < 0 || tier.MinYearsOfService < 0 || tier.MaxActiveBookings < 0 || tier.BookingWindowDays < 0 {
+		return errors.New("tier values cannot be negative")
+	}
+
+	grades := make([]string, 0, len(tier.Grades))
+	for _, grade := range tier.Grades {
+		if grade = strings.TrimSpace(grade); grade != "" && !slices.Contains(grades, grade) {
+			grades = append(grades, grade)
+		}
+	}
+	tier.Grades = grades
+
+	return nil
+}
+
+// yearsOfService menghitung masa kerja penuh user dalam tahun sampai now
+func yearsOfService(user *models.User, now time.Time) int {
+	start := user.ServiceStart()
+	years := now.Year() - start.Year()
+	if start.AddDate(years, 0, 0).After(now) {
+		years--
+	}
+
+	return max(years, 0)
+}
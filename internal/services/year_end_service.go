@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"hotel-point-app/internal/models"
+	"hotel-point-app/internal/repositories"
+)
+
+// CarryOverProjection adalah hasil aturan carry-over untuk satu user
+type CarryOverProjection struct {
+	UserID       primitive.ObjectID `json:"user_id"`
+	Name         string             `json:"name"`
+	Email        string             `json:"email"`
+	PointBalance int                `json:"point_balance"`
+	Unused       int                `json:"unused"` // Saldo ledger pada akhir tahun
+	CarryOver    int                `json:"carry_over"`
+	Forfeit      int                `json:"forfeit"`
+}
+
+// YearEndPreview adalah proyeksi point yang hangus saat tahun point ditutup
+type YearEndPreview struct {
+	Year         int                      `json:"year"`
+	Rule         models.CarryOverSettings `json:"rule"`
+	Closure      *models.YearEndClosure   `json:"closure,omitempty"` // Terisi jika tahun sudah ditutup
+	UsersChecked int                      `json:"users_checked"`
+	TotalForfeit int                      `json:"total_forfeit"`
+	Entries      []CarryOverProjection    `json:"entries"`
+}
+
+type YearEndService interface {
+	// Preview menghitung point yang akan hangus untuk tahun year, includeAll juga menyertakan user tanpa forfeit
+	Preview(year int, includeAll bool) (*YearEndPreview, error)
+	// CloseYear menerapkan aturan carry-over untuk tahun year yang sudah berakhir.
+	// Mengembalikan false jika tahun tersebut sudah pernah ditutup.
+	CloseYear(year int) (*models.YearEndClosure, bool, error)
+}
+
+type yearEndService struct {
+	userRepo        repositories.UserRepository
+	yearEndRepo     repositories.YearEndRepository
+	txManager       repositories.TransactionManager
+	settingsService SettingsService
+	ledger          *pointLedger
+}
+
+func NewYearEndService(
+	userRepo repositories.UserRepository,
+	lotRepo repositories.PointLotRepository,
+	yearEndRepo repositories.YearEndRepository,
+	txManager repositories.TransactionManager,
+	settingsService SettingsService,
+) YearEndService {
+	return &yearEndService{
+		userRepo:        userRepo,
+		yearEndRepo:     yearEndRepo,
+		txManager:       txManager,
+		settingsService: settingsService,
+		ledger:          newPointLedger(userRepo, lotRepo),
+	}
+}
+
+func (s *yearEndService) Preview(year int, includeAll bool) (*YearEndPreview, error) {
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	closure, err := s.yearEndRepo.FindClosure(year)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &YearEndPreview{
+		Year:         year,
+		Rule:         settings.CarryOver,
+		Closure:      closure,
+		UsersChecked: len(users),
+		Entries:      []CarryOverProjection{},
+	}
+
+	for _, user := range users {
+		entry, err := s.project(s.ledger, &user, year, settings.CarryOver)
+		if err != nil {
+			return nil, err
+		}
+
+		preview.TotalForfeit += entry.Forfeit
+		if entry.Forfeit > 0 || includeAll {
+			preview.Entries = append(preview.Entries, *entry)
+		}
+	}
+
+	return preview, nil
+}
+
+func (s *yearEndService) CloseYear(year int) (*models.YearEndClosure, bool, error) {
+	if time.Now().Before(yearEnd(year)) {
+		return nil, false, fmt.Errorf("point year %d has not ended yet", year)
+	}
+
+	closure, err := s.yearEndRepo.FindClosure(year)
+	if err != nil {
+		return nil, false, err
+	}
+	if closure != nil {
+		return closure, false, nil
+	}
+
+	settings, err := s.settingsService.GetSettings()
+	if err != nil {
+		return nil, false, err
+	}
+
+	users, err := s.userRepo.FindAll()
+	if err != nil {
+		return nil, false, err
+	}
+
+	closure = &models.YearEndClosure{
+		Year: year,
+		Rule: settings.CarryOver,
+	}
+
+	failed := 0
+	for _, user := range users {
+		forfeit := 0
+		err := s.txManager.WithTransaction(func(ctx context.Context) error {
+			ledger := s.ledger.withContext(ctx)
+
+			// Forfeit bersifat idempoten per user per tahun. Forfeit yang sudah tercatat pada run
+			// sebelumnya tetap dihitung agar total penutupan mencakup semua user.
+			existing, err := ledger.userRepo.FindLatestPointTransaction(user.ID, []string{models.TransactionYearEndForfeit}, strconv.Itoa(year))
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				forfeit = -existing.Amount
+				return nil
+			}
+
+			current, err := ledger.userRepo.FindByID(user.ID)
+			if err != nil {
+				return err
+			}
+
+			entry, err := s.project(ledger, current, year, settings.CarryOver)
+			if err != nil {
+				return err
+			}
+
+			forfeit = entry.Forfeit
+			if forfeit == 0 {
+				return nil
+			}
+
+			_, err = ledger.debit(user.ID, forfeit, models.TransactionYearEndForfeit, strconv.Itoa(year))
+			return err
+		})
+		if err != nil {
+			// Lanjutkan ke user berikutnya, tahun ini akan ditutup ulang pada run berikutnya
+			log.Printf("Failed to apply year-end carry-over to user %s for %d: %v", user.ID.Hex(), year, err)
+			failed++
+			continue
+		}
+
+		if forfeit > 0 {
+			closure.UsersForfeited++
+			closure.PointsForfeited += forfeit
+		}
+	}
+
+	if failed > 0 {
+		return nil, false, fmt.Errorf("year-end carry-over failed for %d users", failed)
+	}
+
+	closure.ClosedAt = time.Now()
+	if err := s.yearEndRepo.CreateClosure(closure); err != nil {
+		return nil, false, err
+	}
+
+	return closure, true, nil
+}
+
+// project menghitung carry-over user untuk tahun year dari saldo ledger pada akhir tahun, sehingga
+// point yang masuk atau terpakai setelah tahun berakhir tidak mengubah sisa tahun year.
+// Forfeit dibatasi point yang masih tersedia: point yang ditahan booking pending tidak ikut hangus.
+func (s *yearEndService) project(ledger *pointLedger, user *models.User, year int, rule models.CarryOverSettings) (*CarryOverProjection, error) {
+	unused, err := ledger.userRepo.SumPointTransactionsBefore(user.ID, yearEnd(year))
+	if err != nil {
+		return nil, err
+	}
+	unused = max(unused, 0)
+
+	carryOver := unused
+	switch rule.Mode {
+	case models.CarryOverCap:
+		carryOver = min(unused, rule.Cap)
+	case models.CarryOverPercentage:
+		carryOver = unused * rule.Percentage / 100
+	}
+
+	return &CarryOverProjection{
+		UserID:       user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		PointBalance: user.PointBalance,
+		Unused:       unused,
+		CarryOver:    carryOver,
+		Forfeit:      min(unused-carryOver, max(user.AvailablePoints(), 0)),
+	}, nil
+}
+
+// yearEnd adalah awal tahun berikutnya, batas eksklusif tahun point year
+func yearEnd(year int) time.Time {
+	return time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,159 @@
+// Package ical membaca event dari file iCalendar (RFC 5545) seperti kalender hari libur nasional.
+// Hanya VEVENT dengan DTSTART, DTEND, SUMMARY dan UID yang dibaca; aturan pengulangan diabaikan.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// MaxEvents adalah jumlah VEVENT terbanyak yang dibaca dari satu file; file yang lebih besar ditolak
+// sebelum seluruh isinya diurai
+const MaxEvents = 1000
+
+// Event adalah satu VEVENT. Start dan End hanya berisi tanggal (UTC tengah malam),
+// End eksklusif sehingga event satu hari memiliki End = Start + 1 hari.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Dates mengembalikan setiap tanggal yang dicakup event
+func (e Event) Dates() []time.Time {
+	var dates []time.Time
+	for d := e.Start; d.Before(e.End); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// Parse membaca semua VEVENT dari r
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	foundCalendar := false
+
+	for i, line := range lines {
+		name, params, value := splitLine(line)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			foundCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", i+1, current.Summary)
+			}
+			if current.End.IsZero() || !current.End.After(current.Start) {
+				current.End = current.Start.AddDate(0, 0, 1)
+			}
+			if len(events) == MaxEvents {
+				return nil, fmt.Errorf("calendar has more than %d events", MaxEvents)
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			// Properti di luar VEVENT (VCALENDAR, VTIMEZONE, dll.) diabaikan
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DTSTART" || name == "DTEND":
+			date, err := parseDate(value, params)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %v", i+1, name, err)
+			}
+			if name == "DTSTART" {
+				current.Start = date
+			} else {
+				current.End = date
+			}
+		}
+	}
+
+	if !foundCalendar {
+		return nil, errors.New("not an iCalendar file")
+	}
+	if current != nil {
+		return nil, errors.New("unterminated VEVENT")
+	}
+
+	return events, nil
+}
+
+// unfold menggabungkan baris lanjutan (diawali spasi atau tab) ke baris sebelumnya
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitLine memisahkan "NAME;PARAM=VALUE:value" menjadi nama, parameter dan nilai
+func splitLine(line string) (string, map[string]string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(line), nil, ""
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
+
+// parseDate membaca nilai DATE atau DATE-TIME dan hanya mengambil tanggalnya
+func parseDate(value string, params map[string]string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("%q is not a date", value)
+	}
+
+	// DATE-TIME UTC dikonversi ke tanggal UTC, DATE-TIME lokal atau ber-TZID dipakai apa adanya
+	if params["VALUE"] != "DATE" && strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	return time.Parse("20060102", value[:8])
+}
+
+// unescape mengembalikan karakter yang di-escape pada nilai teks
+func unescape(value string) string {
+	replacer := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(replacer.Replace(value))
+}
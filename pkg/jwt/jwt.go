@@ -97,3 +97,64 @@ func GetTokenRemainingValidity(tokenString, secret string) (int64, error) {
 
 	return remaining, nil
 }
+
+// QuoteNight adalah biaya satu malam yang dikunci pada quote
+type QuoteNight struct {
+	Date           string `json:"date"` // Format YYYY-MM-DD
+	DayType        string `json:"day_type"`
+	Name           string `json:"name,omitempty"`
+	PointCost      int    `json:"point_cost"`
+	DiscountPoints int    `json:"discount_points,omitempty"`
+	RuleID         string `json:"rule_id,omitempty"`
+}
+
+// QuoteDiscount adalah potongan yang dikunci pada quote
+type QuoteDiscount struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Points      int    `json:"points"`
+}
+
+// QuoteClaims adalah claims quote harga booking yang ditandatangani
+type QuoteClaims struct {
+	UserID    string          `json:"user_id"`
+	HotelID   string          `json:"hotel_id"`
+	RoomID    string          `json:"room_id"`
+	CheckIn   string          `json:"check_in"`  // Format YYYY-MM-DD
+	CheckOut  string          `json:"check_out"` // Format YYYY-MM-DD
+	PointCost int             `json:"point_cost"`
+	Nights    []QuoteNight    `json:"nights"`
+	Discounts []QuoteDiscount `json:"discounts,omitempty"`
+	jwt.StandardClaims
+}
+
+// GenerateQuoteToken menandatangani quote yang berlaku sampai expiresAt.
+// Gunakan secret yang berbeda dari token autentikasi agar quote tidak dapat dipakai untuk login.
+func GenerateQuoteToken(claims *QuoteClaims, secret string, expiresAt time.Time) (string, error) {
+	claims.StandardClaims = jwt.StandardClaims{
+		ExpiresAt: expiresAt.Unix(),
+		IssuedAt:  time.Now().Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ValidateQuoteToken memvalidasi tanda tangan dan masa berlaku quote
+func ValidateQuoteToken(tokenString, secret string) (*QuoteClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &QuoteClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*QuoteClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("Invalid quote token")
+}
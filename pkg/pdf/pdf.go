@@ -0,0 +1,124 @@
+// Package pdf menulis dokumen PDF sederhana berisi teks tanpa dependensi eksternal.
+// Hanya font standar Helvetica dan Helvetica-Bold yang didukung.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ukuran halaman A4 dalam point (1/72 inch)
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+)
+
+// Font standar yang tersedia
+const (
+	FontRegular = "F1" // Helvetica
+	FontBold    = "F2" // Helvetica-Bold
+)
+
+// Document adalah dokumen PDF yang dibangun halaman per halaman
+type Document struct {
+	pages []*bytes.Buffer
+}
+
+// New membuat dokumen kosong, gunakan AddPage sebelum menulis teks
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage menambah halaman baru, teks berikutnya ditulis ke halaman ini
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount mengembalikan jumlah halaman
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// Text menulis teks pada posisi x, y (dari kiri bawah halaman) di halaman terakhir
+func (d *Document) Text(x, y float64, font string, size float64, text string) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// Line menggambar garis dari (x1, y1) ke (x2, y2) di halaman terakhir
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.pages[len(d.pages)-1], "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// WriteTo menulis dokumen lengkap ke w
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+
+	// Objek 1: catalog, 2: pages, 3-4: font, lalu pasangan page dan content per halaman
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 6+i*2,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// escape mengubah teks menjadi string literal PDF. Karakter di luar Latin-1 diganti "?".
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(byte(r))
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 256:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}